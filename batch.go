@@ -0,0 +1,130 @@
+package trier
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// TryBatch tries every one of fns in order
+// with a single loop, building the config
+// from opts just once for the whole batch,
+// which keeps overhead down for chains with
+// hundreds of generated steps. Like TrySeq,
+// it stops at the first error unless
+// WithJoin is given, and nothing is done
+// if the Trier already holds an error
+func (t *Trier) TryBatch(fns []func() error, opts ...TryOption) *Trier {
+	c := tryConfig{limit: 1}
+	if len(opts) != 0 {
+		c = newTryConfig(opts)
+	}
+
+	for _, fn := range fns {
+		if t.timedOut || (t.failed() && !c.join) {
+			break
+		}
+
+		c.plain = fn
+		t.try(nil, &c)
+	}
+
+	return t
+}
+
+// TryBatchParallel is like TryBatch, but tries
+// fns on up to limit goroutines at once (or
+// GOMAXPROCS if limit is less than or equal
+// to zero). Once one of fns fails, those not
+// yet started are skipped unless WithJoin is
+// given. Errors are recorded in the order of
+// fns, not the order they happened in
+func (t *Trier) TryBatchParallel(limit int, fns []func() error, opts ...TryOption) *Trier {
+	c := newTryConfig(opts)
+
+	if t.timedOut || (t.failed() && !c.join) {
+		return t
+	}
+
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+
+	workers := min(limit, len(fns))
+
+	base := t.steps
+	t.steps += len(fns)
+
+	var (
+		wg       sync.WaitGroup
+		next     atomic.Int64
+		failed   atomic.Bool
+		timedOut atomic.Bool
+		errs     = make([]error, len(fns))
+		results  []StepResult
+	)
+
+	if t.keepSteps {
+		results = make([]StepResult, len(fns))
+	}
+
+	wg.Add(workers)
+
+	for range workers {
+		go func() {
+			defer wg.Done()
+
+			// each worker reuses a single fork
+			// and config for every fn it tries
+			f := t.fork(0)
+			wc := c
+
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= len(fns) || (failed.Load() && !wc.join) {
+					return
+				}
+
+				f.errs = f.errs[:0]
+				f.steps = base + i
+				wc.plain = fns[i]
+
+				// errors returned by try have
+				// already been through any errFn
+				if _, err := f.try(nil, &wc); err != nil {
+					errs[i] = err
+					failed.Store(true)
+				}
+
+				if len(f.results) != 0 {
+					results[i] = f.results[0]
+					f.results = f.results[:0]
+				}
+
+				if f.timedOut {
+					timedOut.Store(true)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		t.store(err)
+	}
+
+	// fns never tried are left zero, while
+	// those tried made an attempt or failed
+	// validation with an error
+	for _, result := range results {
+		if result.Attempts != 0 || result.Err != nil {
+			t.results = append(t.results, result)
+		}
+	}
+
+	t.timedOut = t.timedOut || timedOut.Load()
+
+	return t
+}
@@ -0,0 +1,192 @@
+package trier
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// batch returns n funcs that record that they
+// ran in ran, failing for any index in fail
+func batch(n int, ran *[]int, fail ...int) []func() error {
+	fns := make([]func() error, n)
+
+	for i := range fns {
+		fns[i] = func() error {
+			*ran = append(*ran, i)
+
+			for _, f := range fail {
+				if f == i {
+					return fmt.Errorf("fn %d failed", i)
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return fns
+}
+
+func TestTrierTryBatch(t *testing.T) {
+	// Arrange
+	var ran []int
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryBatch(batch(4, &ran))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, []int{0, 1, 2, 3}, ran)
+}
+
+func TestTrierTryBatchStopsAtError(t *testing.T) {
+	// Arrange
+	var ran []int
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryBatch(batch(4, &ran, 1))
+
+	// Assert
+	assert.EqualError(t, tr.Err(), "fn 1 failed")
+	assert.Equal(t, []int{0, 1}, ran)
+}
+
+func TestTrierTryBatchWithJoin(t *testing.T) {
+	// Arrange
+	var ran []int
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryBatch(batch(4, &ran, 1, 3), WithJoin(), WithStepName("batch"))
+
+	// Assert
+	assert.EqualError(t, tr.Err(), "batch: fn 3 failed\nbatch: fn 1 failed")
+	assert.Equal(t, []int{0, 1, 2, 3}, ran)
+}
+
+func TestTrierTryBatchAlreadyFailed(t *testing.T) {
+	// Arrange
+	var ran []int
+
+	tr := NewTrier().TryFunc(fail)
+
+	// Act
+	tr.TryBatch(batch(2, &ran))
+
+	// Assert
+	assert.Empty(t, ran)
+}
+
+func TestTrierTryBatchParallel(t *testing.T) {
+	// Arrange
+	var calls, inFlight, most atomic.Int32
+
+	fns := make([]func() error, 20)
+	for i := range fns {
+		fns[i] = func() error {
+			calls.Add(1)
+
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+
+			for {
+				m := most.Load()
+				if n <= m || most.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			return nil
+		}
+	}
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryBatchParallel(4, fns)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, int32(20), calls.Load())
+	assert.LessOrEqual(t, most.Load(), int32(4))
+}
+
+func TestTrierTryBatchParallelJoinsInOrder(t *testing.T) {
+	// Arrange
+	fns := make([]func() error, 6)
+	for i := range fns {
+		fns[i] = func() error {
+			if i%2 == 1 {
+				time.Sleep(time.Duration(6-i) * time.Millisecond)
+				return fmt.Errorf("fn %d failed", i)
+			}
+			return nil
+		}
+	}
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryBatchParallel(0, fns, WithJoin())
+
+	// Assert
+	assert.EqualError(t, tr.Err(), "fn 5 failed\nfn 3 failed\nfn 1 failed")
+}
+
+func TestTrierTryBatchParallelStopsAtError(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+
+	errFailed := errors.New("failed")
+
+	fns := make([]func() error, 10)
+	for i := range fns {
+		fns[i] = func() error {
+			calls.Add(1)
+			return errFailed
+		}
+	}
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryBatchParallel(1, fns)
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), errFailed)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestTrierTryBatchParallelRetries(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+
+	fns := []func() error{
+		func() error {
+			if calls.Add(1) < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		},
+	}
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryBatchParallel(2, fns, WithRetry(3))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, int32(3), calls.Load())
+}
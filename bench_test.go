@@ -0,0 +1,266 @@
+package trier
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errBench = errors.New("bench")
+
+func nop(args ...any) error {
+	return nil
+}
+
+func BenchmarkTrierTry(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		NewTrier().Try(nop).Try(nop).Try(nop)
+	}
+}
+
+func BenchmarkTrierTryArgs(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		NewTrier().Try(nop, 1, "two")
+	}
+}
+
+func BenchmarkTrierTryFunc(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		NewTrier().TryFunc(func() error {
+			return nil
+		})
+	}
+}
+
+func BenchmarkTrierTryError(b *testing.B) {
+	b.ReportAllocs()
+
+	fail := func(args ...any) error {
+		return errBench
+	}
+
+	for i := 0; i < b.N; i++ {
+		NewTrier().Try(fail).Try(nop)
+	}
+}
+
+func BenchmarkTrierTryRetry(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		NewTrier().TryRetry(3, nop)
+	}
+}
+
+func BenchmarkTrierTryJoin(b *testing.B) {
+	b.ReportAllocs()
+
+	fail := func(args ...any) error {
+		return errBench
+	}
+
+	for i := 0; i < b.N; i++ {
+		NewTrier().TryJoin(fail).TryJoin(fail).TryJoin(fail)
+	}
+}
+
+func BenchmarkTrierReused(b *testing.B) {
+	b.ReportAllocs()
+
+	tr := NewTrier()
+
+	for i := 0; i < b.N; i++ {
+		tr.Try(nop).Try(nop).Try(nop)
+	}
+}
+
+func BenchmarkTrierGet(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		tr := Get()
+		tr.Try(nop).Try(nop).Try(nop)
+		tr.Release()
+	}
+}
+
+func BenchmarkTrierTryFuncReused(b *testing.B) {
+	b.ReportAllocs()
+
+	tr := NewTrier()
+
+	fn := func() error {
+		return nil
+	}
+
+	for i := 0; i < b.N; i++ {
+		tr.TryFunc(fn).TryRetryFunc(3, fn).TryJoinFunc(fn)
+	}
+}
+
+func BenchmarkTrierTryWithReused(b *testing.B) {
+	b.ReportAllocs()
+
+	tr := NewTrier()
+
+	for i := 0; i < b.N; i++ {
+		tr.TryWith(nop)
+	}
+}
+
+func BenchmarkTrierTryJoinMany(b *testing.B) {
+	b.ReportAllocs()
+
+	fail := func(args ...any) error {
+		return errBench
+	}
+
+	for i := 0; i < b.N; i++ {
+		tr := NewTrier()
+		for j := 0; j < 100; j++ {
+			tr.TryJoin(fail)
+		}
+		_ = tr.Err().Error()
+	}
+}
+
+func BenchmarkImmutableTrierTry(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = NewImmutableTrier().Try(nop).TryRetry(3, nop).TryJoin(nop).Err()
+	}
+}
+
+func BenchmarkClassifyParallel(b *testing.B) {
+	b.ReportAllocs()
+
+	retryIf := RetryIf()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			retryIf(errBench)
+		}
+	})
+}
+
+func BenchmarkPolicyParallel(b *testing.B) {
+	b.ReportAllocs()
+
+	policy := RetryPolicy{
+		Attempts: 3,
+		RetryIf:  RetryIf(),
+	}
+
+	b.RunParallel(func(pb *testing.PB) {
+		tr := NewTrier()
+
+		for pb.Next() {
+			tr.TryWith(nop, WithPolicy(policy))
+		}
+	})
+}
+
+func BenchmarkTrierTryFuncLoop(b *testing.B) {
+	b.ReportAllocs()
+
+	fns := make([]func() error, 100)
+	for i := range fns {
+		fns[i] = pass
+	}
+
+	tr := NewTrier()
+
+	for i := 0; i < b.N; i++ {
+		for _, fn := range fns {
+			tr.TryFunc(fn, WithRetry(3))
+		}
+	}
+}
+
+func BenchmarkTrierTryBatch(b *testing.B) {
+	b.ReportAllocs()
+
+	fns := make([]func() error, 100)
+	for i := range fns {
+		fns[i] = pass
+	}
+
+	tr := NewTrier()
+
+	for i := 0; i < b.N; i++ {
+		tr.TryBatch(fns, WithRetry(3))
+	}
+}
+
+// step is called through a variable by the
+// bare benchmarks, just as Try calls fn
+var step = nop
+
+func BenchmarkBareErrCheck(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := step(); err != nil {
+			continue
+		}
+		if err := step(); err != nil {
+			continue
+		}
+		if err := step(); err != nil {
+			continue
+		}
+	}
+}
+
+func BenchmarkTrierTryChained(b *testing.B) {
+	b.ReportAllocs()
+
+	var tr Trier
+
+	for i := 0; i < b.N; i++ {
+		tr.Try(step).Try(step).Try(step)
+	}
+}
+
+func BenchmarkTrierTryChainedFailed(b *testing.B) {
+	b.ReportAllocs()
+
+	tr := NewTrier().TryFunc(func() error {
+		return errBench
+	})
+
+	for i := 0; i < b.N; i++ {
+		tr.Try(step).Try(step).Try(step)
+	}
+}
+
+func BenchmarkTrierTryRetryBackoff(b *testing.B) {
+	b.ReportAllocs()
+
+	tr := NewTrier()
+
+	attempt := 0
+
+	fn := func(args ...any) error {
+		attempt++
+		if attempt%2 == 1 {
+			return errBench
+		}
+		return nil
+	}
+
+	backoff := func(i int) time.Duration {
+		return time.Nanosecond
+	}
+
+	for i := 0; i < b.N; i++ {
+		tr.TryRetryBackoff(2, backoff, fn)
+	}
+}
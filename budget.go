@@ -0,0 +1,107 @@
+package trier
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExhausted is recorded in place of
+// trying a step once the Trier's ErrorBudget
+// has been used up
+var ErrBudgetExhausted = errors.New("error budget exhausted")
+
+// ErrorBudget allows up to n failures within
+// a sliding window. Once n failures have been
+// recorded within the window, the budget is
+// exhausted until enough of them age out. An
+// ErrorBudget is safe for concurrent use, so
+// a single one can be shared between Triers
+// to shed load across all of them at once
+type ErrorBudget struct {
+	mu       sync.Mutex
+	n        int
+	window   time.Duration
+	failures []time.Time
+}
+
+// NewErrorBudget creates a new *ErrorBudget
+// allowing n failures within window. If n
+// is less than or equal to zero, there's
+// no budget, so it's never exhausted
+func NewErrorBudget(n int, window time.Duration) *ErrorBudget {
+	return &ErrorBudget{
+		n:        n,
+		window:   window,
+		failures: make([]time.Time, 0, max(n, 0)),
+	}
+}
+
+// Exhausted reports whether n failures
+// have been recorded within the window
+func (b *ErrorBudget) Exhausted() bool {
+	if b.n <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.prune(time.Now())
+
+	return len(b.failures) >= b.n
+}
+
+// Fail records a failure against the budget
+func (b *ErrorBudget) Fail() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	b.prune(now)
+
+	if b.n <= 0 {
+		return
+	}
+
+	// only the latest n failures matter
+	if len(b.failures) == b.n {
+		b.failures = append(b.failures[:0], b.failures[1:]...)
+	}
+
+	b.failures = append(b.failures, now)
+}
+
+// prune drops failures that
+// have aged out of the window
+func (b *ErrorBudget) prune(now time.Time) {
+	i := 0
+	for i < len(b.failures) && now.Sub(b.failures[i]) >= b.window {
+		i++
+	}
+
+	if i != 0 {
+		b.failures = append(b.failures[:0], b.failures[i:]...)
+	}
+}
+
+// WithErrorBudget gives the Trier its own
+// ErrorBudget of n failures within window.
+// Every step that fails counts against it,
+// and once it is exhausted further steps
+// are skipped immediately, recording
+// ErrBudgetExhausted (once) instead
+func WithErrorBudget(n int, window time.Duration) Option {
+	return WithSharedErrorBudget(NewErrorBudget(n, window))
+}
+
+// WithSharedErrorBudget is like WithErrorBudget,
+// but uses b, which may be shared between
+// any number of Triers
+func WithSharedErrorBudget(b *ErrorBudget) Option {
+	return func(t *Trier) {
+		t.budget = b
+		t.guarded = true
+	}
+}
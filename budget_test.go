@@ -0,0 +1,115 @@
+package trier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewErrorBudget(t *testing.T) {
+	// Act
+	b := NewErrorBudget(2, time.Minute)
+
+	// Assert
+	assert.False(t, b.Exhausted())
+}
+
+func TestErrorBudgetExhausted(t *testing.T) {
+	// Arrange
+	b := NewErrorBudget(2, time.Minute)
+
+	// Act
+	b.Fail()
+	b.Fail()
+
+	// Assert
+	assert.True(t, b.Exhausted())
+}
+
+func TestErrorBudgetZero(t *testing.T) {
+	// Arrange
+	b := NewErrorBudget(0, time.Minute)
+
+	// Act
+	b.Fail()
+
+	// Assert
+	assert.False(t, b.Exhausted())
+}
+
+func TestTrierWithErrorBudgetNil(t *testing.T) {
+	// Arrange
+	b := NewErrorBudget(1, time.Minute)
+	tr := NewTrier(WithSharedErrorBudget(b))
+
+	tr.Try(passOrFail, true).
+		Nil()
+
+	shed := tr.Try(passOrFail).NilErr()
+
+	// Act
+	skipped := tr.Try(passOrFail).Err()
+
+	// Assert
+	assert.ErrorIs(t, shed, ErrBudgetExhausted)
+	assert.ErrorIs(t, skipped, ErrBudgetExhausted)
+}
+
+func TestErrorBudgetWindow(t *testing.T) {
+	// Arrange
+	b := NewErrorBudget(1, 10*time.Millisecond)
+
+	// Act
+	b.Fail()
+	exhausted := b.Exhausted()
+	time.Sleep(20 * time.Millisecond)
+
+	// Assert
+	assert.True(t, exhausted)
+	assert.False(t, b.Exhausted())
+}
+
+func TestTrierWithErrorBudget(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithErrorBudget(2, time.Minute))
+
+	calls := 0
+
+	counted := func(args ...any) error {
+		calls++
+		return fail()
+	}
+
+	// Act
+	tr.TryJoin(counted).
+		TryJoin(counted).
+		TryJoin(counted).
+		TryJoin(counted)
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrBudgetExhausted)
+	assert.Equal(t, "error budget exhausted\nfailed fail\nfailed fail", tr.Err().Error())
+	assert.Equal(t, 2, calls)
+}
+
+func TestTrierWithSharedErrorBudget(t *testing.T) {
+	// Arrange
+	b := NewErrorBudget(1, time.Minute)
+
+	first := NewTrier(WithSharedErrorBudget(b))
+	second := NewTrier(WithSharedErrorBudget(b))
+
+	calls := 0
+
+	// Act
+	first.TryFunc(fail)
+	second.TryFunc(func() error {
+		calls++
+		return nil
+	})
+
+	// Assert
+	assert.ErrorIs(t, second.Err(), ErrBudgetExhausted)
+	assert.Equal(t, 0, calls)
+}
@@ -0,0 +1,352 @@
+package trier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Chain is a lazily built sequence of Steps.
+// Nothing is tried until Execute is called,
+// and since every call to Execute tries the
+// steps on a fresh Trier, a Chain can be
+// defined once and executed as many times as
+// needed (e.g. once per job a worker picks up)
+type Chain struct {
+	opts  []Option
+	steps []Step
+}
+
+// Report describes what happened to each
+// step during a single Execute
+type Report struct {
+	// Name is the name given to the
+	// Chain with WithName, if any
+	Name  string
+	Steps []StepResult
+}
+
+// StepResult describes what happened
+// to a single step of a Chain
+type StepResult struct {
+	// Index is the step's position in the Chain
+	Index int
+	Name  string
+	// Attempts is how many times the step's
+	// Fn was called, zero if it was skipped
+	Attempts int
+	// Err is the error recorded for
+	// the step, if any
+	Err error
+	// Skipped reports whether the step
+	// wasn't tried because an earlier
+	// step (or one of its dependencies)
+	// had already failed
+	Skipped bool
+}
+
+// NewChain creates a new, empty *Chain. opts
+// configure the Trier created for each Execute
+func NewChain(opts ...Option) *Chain {
+	return &Chain{opts: opts}
+}
+
+// Then appends steps to the Chain and
+// returns the *Chain that called it
+func (c *Chain) Then(steps ...Step) *Chain {
+	c.steps = append(c.steps, steps...)
+	return c
+}
+
+// Execute tries every step of the Chain in
+// order on a fresh Trier, returning a Report
+// of the run along with the Trier's error.
+// Any args are passed to every step after
+// the step's own Args. Runs are independent
+// of each other, so Execute may be called
+// concurrently as long as the Chain isn't
+// being added to at the same time
+func (c *Chain) Execute(args ...any) (Report, error) {
+	t := NewTrier(c.opts...)
+
+	report := Report{
+		Name:  t.name,
+		Steps: make([]StepResult, len(c.steps)),
+	}
+
+	for i, step := range c.steps {
+		report.Steps[i] = c.run(t, i, step, args)
+	}
+
+	return report, t.Err()
+}
+
+// Resume is like Execute, but tries the steps
+// on t instead of a fresh Trier, picking up
+// where t left off. Steps t already got past
+// are reported as Skipped, and if t holds an
+// error, it's cleared and the step that failed
+// is tried again. Saving t with MarshalJSON
+// after a failed run lets another process
+// restore it with UnmarshalJSON and resume
+// the run, and a fresh t runs every step
+func (c *Chain) Resume(t *Trier, args ...any) (Report, error) {
+	// where to start is worked out before
+	// applying options, some of which
+	// (like WithErrorCapacity) replace
+	// the errors t holds
+	start := t.steps
+	if t.failed() {
+		start--
+	}
+	start = max(0, min(start, len(c.steps)))
+
+	t.Nil()
+
+	for _, opt := range c.opts {
+		opt(t)
+	}
+
+	t.steps = start
+	t.timedOut = false
+
+	report := Report{
+		Name:  t.name,
+		Steps: make([]StepResult, len(c.steps)),
+	}
+
+	for i, step := range c.steps {
+		if i < start {
+			report.Steps[i] = StepResult{Index: i, Name: step.Name, Skipped: true}
+			continue
+		}
+
+		report.Steps[i] = c.run(t, i, step, args)
+	}
+
+	return report, t.Err()
+}
+
+// run tries step on t, passing it args
+// after its own, and reports the result
+func (c *Chain) run(t *Trier, i int, step Step, args []any) StepResult {
+	if len(args) != 0 {
+		step.Args = append(step.Args[:len(step.Args):len(step.Args)], args...)
+	}
+
+	cfg := newTryConfig(step.options())
+
+	attempts, err := t.try(step.Fn, &cfg)
+
+	return StepResult{
+		Index:    i,
+		Name:     step.Name,
+		Attempts: attempts,
+		Err:      err,
+		Skipped:  attempts == 0,
+	}
+}
+
+// ExecuteParallel is like Execute, but tries
+// steps concurrently, running at most limit
+// steps at a time (or all of them at once if
+// limit is less than or equal to zero). A step
+// is only started once every step it DependsOn
+// has succeeded. Like Execute, once any step
+// fails no further steps are started, though
+// steps already running are left to finish,
+// and the same goes for once ctx is done.
+// Errors from every failed step are joined
+func (c *Chain) ExecuteParallel(ctx context.Context, limit int, args ...any) (Report, error) {
+	var sem Semaphore
+	if limit > 0 {
+		sem = make(limiter, limit)
+	}
+
+	return c.executeParallel(ctx, sem, false, args)
+}
+
+// ExecuteWeighted is like ExecuteParallel, but
+// rather than limiting how many steps run at once,
+// every step acquires its Weight from sem before
+// it starts. Sharing sem (such as a
+// *semaphore.Weighted from golang.org/x/sync)
+// between Chains, or with TryWith calls using
+// WithSemaphore, lets heavy and light steps
+// across a whole process share one budget.
+// A step whose weight can't be acquired
+// before ctx is done is skipped
+func (c *Chain) ExecuteWeighted(ctx context.Context, sem Semaphore, args ...any) (Report, error) {
+	return c.executeParallel(ctx, sem, true, args)
+}
+
+func (c *Chain) executeParallel(ctx context.Context, sem Semaphore, weighted bool, args []any) (Report, error) {
+	t := NewTrier(c.opts...)
+
+	report := Report{
+		Name:  t.name,
+		Steps: make([]StepResult, len(c.steps)),
+	}
+
+	deps, err := c.dependencies()
+	if err != nil {
+		for i, step := range c.steps {
+			report.Steps[i] = StepResult{Index: i, Name: step.Name, Skipped: true}
+		}
+
+		t.record(err)
+
+		return report, t.Err()
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed bool
+		done   = make([]chan struct{}, len(c.steps))
+	)
+
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	for i, step := range c.steps {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+
+			skipped := StepResult{Index: i, Name: step.Name, Skipped: true}
+
+			for _, d := range deps[i] {
+				<-done[d]
+			}
+
+			acquired := true
+
+			if sem != nil {
+				weight := int64(1)
+				if weighted {
+					weight = step.weight()
+				}
+
+				acquired = sem.Acquire(ctx, weight) == nil
+				if acquired {
+					defer sem.Release(weight)
+				}
+			}
+
+			mu.Lock()
+			ok := acquired && !failed && ctx.Err() == nil
+			for _, d := range deps[i] {
+				ok = ok && report.Steps[d].Err == nil && !report.Steps[d].Skipped
+			}
+			mu.Unlock()
+
+			if !ok {
+				mu.Lock()
+				report.Steps[i] = skipped
+				mu.Unlock()
+				return
+			}
+
+			result := c.run(t.fork(i), i, step, args)
+
+			mu.Lock()
+			report.Steps[i] = result
+			failed = failed || result.Err != nil
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	// errors from forks have already
+	// been through any errFn
+	for _, result := range report.Steps {
+		t.store(result.Err)
+	}
+
+	if !failed {
+		t.record(ctx.Err())
+	}
+
+	return report, t.Err()
+}
+
+// fork returns a copy of t, without any
+// error, for trying step on its own
+func (t *Trier) fork(step int) *Trier {
+	c := *t
+
+	c.errs = nil
+	c.results = nil
+	c.dropped = 0
+	c.steps = step
+
+	return &c
+}
+
+// dependencies resolves the DependsOn names
+// of every step into the indices of the
+// steps they refer to
+func (c *Chain) dependencies() ([][]int, error) {
+	indices := make(map[string]int, len(c.steps))
+
+	for i, step := range c.steps {
+		if step.Name == "" {
+			continue
+		}
+
+		if _, ok := indices[step.Name]; ok {
+			return nil, fmt.Errorf("duplicate step name %q", step.Name)
+		}
+
+		indices[step.Name] = i
+	}
+
+	deps := make([][]int, len(c.steps))
+	pending := make([]int, len(c.steps))
+	dependents := make([][]int, len(c.steps))
+
+	for i, step := range c.steps {
+		for _, name := range step.DependsOn {
+			d, ok := indices[name]
+			if !ok {
+				return nil, fmt.Errorf("step %d depends on unknown step %q", i, name)
+			}
+
+			deps[i] = append(deps[i], d)
+			dependents[d] = append(dependents[d], i)
+			pending[i]++
+		}
+	}
+
+	var ready []int
+	for i, n := range pending {
+		if n == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	resolved := 0
+	for len(ready) != 0 {
+		i := ready[0]
+		ready = ready[1:]
+		resolved++
+
+		for _, d := range dependents[i] {
+			pending[d]--
+			if pending[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	if resolved != len(c.steps) {
+		return nil, fmt.Errorf("steps have a dependency cycle")
+	}
+
+	return deps, nil
+}
@@ -0,0 +1,232 @@
+package trier
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChain(t *testing.T) {
+	// Act
+	c := NewChain()
+
+	// Assert
+	assert.NotNil(t, c)
+}
+
+func TestChainExecute(t *testing.T) {
+	// Arrange
+	calls := 0
+
+	c := NewChain().Then(
+		Step{Name: "first", Fn: func(args ...any) error {
+			calls++
+			return nil
+		}},
+		Step{Name: "second", Fn: passOrFail},
+	)
+
+	// Act
+	report, err := c.Execute()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Len(t, report.Steps, 2)
+	assert.Equal(t, StepResult{Index: 1, Name: "second", Attempts: 1}, report.Steps[1])
+}
+
+func TestChainExecuteError(t *testing.T) {
+	// Arrange
+	c := NewChain(WithName("chain")).Then(
+		Step{Name: "first", Fn: passOrFail, Args: []any{true}},
+		Step{Name: "second", Fn: passOrFail},
+	)
+
+	// Act
+	report, err := c.Execute()
+
+	// Assert
+	assert.Equal(t, "chain: first: failed passOrFail", err.Error())
+	assert.Equal(t, "chain", report.Name)
+	assert.Equal(t, "first: failed passOrFail", report.Steps[0].Err.Error())
+	assert.True(t, report.Steps[1].Skipped)
+	assert.Equal(t, 0, report.Steps[1].Attempts)
+}
+
+func TestChainExecuteRepeatedly(t *testing.T) {
+	// Arrange
+	c := NewChain().Then(Step{Name: "job", Fn: failIfString})
+
+	// Act
+	first, firstErr := c.Execute("hi")
+	second, secondErr := c.Execute(0)
+
+	// Assert
+	assert.NotNil(t, firstErr)
+	assert.NotNil(t, first.Steps[0].Err)
+	assert.Nil(t, secondErr)
+	assert.Nil(t, second.Steps[0].Err)
+}
+
+func TestChainExecuteRetryAttempts(t *testing.T) {
+	// Arrange
+	calls := 0
+
+	c := NewChain().Then(Step{
+		Fn: func(args ...any) error {
+			calls++
+			if calls < 3 {
+				return fail()
+			}
+			return nil
+		},
+		Retry: 5,
+	})
+
+	// Act
+	report, err := c.Execute()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.Steps[0].Attempts)
+}
+
+func TestChainExecuteParallel(t *testing.T) {
+	// Arrange
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+
+	record := func(name string) func(args ...any) error {
+		return func(args ...any) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	c := NewChain().Then(
+		Step{Name: "last", Fn: record("last"), DependsOn: []string{"a", "b"}},
+		Step{Name: "a", Fn: record("a")},
+		Step{Name: "b", Fn: record("b"), DependsOn: []string{"a"}},
+	)
+
+	// Act
+	report, err := c.ExecuteParallel(context.Background(), 2)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "last"}, order)
+	for _, result := range report.Steps {
+		assert.Equal(t, 1, result.Attempts)
+	}
+}
+
+func TestChainExecuteParallelConcurrent(t *testing.T) {
+	// Arrange
+	var running, most atomic.Int32
+
+	step := Step{Fn: func(args ...any) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			m := most.Load()
+			if n <= m || most.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}}
+
+	c := NewChain().Then(step, step, step, step)
+
+	// Act
+	_, err := c.ExecuteParallel(context.Background(), 2)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), most.Load())
+}
+
+func TestChainExecuteParallelDependencyFailed(t *testing.T) {
+	// Arrange
+	c := NewChain().Then(
+		Step{Name: "a", Fn: passOrFail, Args: []any{true}},
+		Step{Name: "b", Fn: passOrFail, DependsOn: []string{"a"}},
+	)
+
+	// Act
+	report, err := c.ExecuteParallel(context.Background(), 0)
+
+	// Assert
+	assert.Equal(t, "a: failed passOrFail", err.Error())
+	assert.True(t, report.Steps[1].Skipped)
+}
+
+func TestChainExecuteParallelUnknownDependency(t *testing.T) {
+	// Arrange
+	c := NewChain().Then(Step{Name: "a", Fn: passOrFail, DependsOn: []string{"b"}})
+
+	// Act
+	report, err := c.ExecuteParallel(context.Background(), 0)
+
+	// Assert
+	assert.Equal(t, `step 0 depends on unknown step "b"`, err.Error())
+	assert.True(t, report.Steps[0].Skipped)
+}
+
+func TestChainExecuteParallelCycle(t *testing.T) {
+	// Arrange
+	c := NewChain().Then(
+		Step{Name: "a", Fn: passOrFail, DependsOn: []string{"b"}},
+		Step{Name: "b", Fn: passOrFail, DependsOn: []string{"a"}},
+	)
+
+	// Act
+	_, err := c.ExecuteParallel(context.Background(), 0)
+
+	// Assert
+	assert.Equal(t, "steps have a dependency cycle", err.Error())
+}
+
+func TestChainExecuteParallelCanceled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := NewChain().Then(Step{Fn: passOrFail})
+
+	// Act
+	report, err := c.ExecuteParallel(ctx, 1)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, report.Steps[0].Skipped)
+}
+
+func TestChainExecuteParallelWithErrFn(t *testing.T) {
+	// Arrange
+	calls := 0
+
+	c := NewChain(WithErrFn(func(err error) error {
+		calls++
+		return err
+	})).Then(Step{Fn: passOrFail, Args: []any{true}})
+
+	// Act
+	_, err := c.ExecuteParallel(context.Background(), 0)
+
+	// Assert
+	assert.Equal(t, "failed passOrFail", err.Error())
+	assert.Equal(t, 1, calls)
+}
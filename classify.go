@@ -0,0 +1,185 @@
+package trier
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Class is how a Classifier classifies an error
+type Class int
+
+const (
+	// Unknown means the Classifier
+	// has no opinion on the error
+	Unknown Class = iota
+	// Retryable means the error is
+	// likely to go away if retried
+	Retryable
+	// Permanent means retrying the
+	// error is pointless
+	Permanent
+)
+
+func (c Class) String() string {
+	switch c {
+	case Retryable:
+		return "retryable"
+	case Permanent:
+		return "permanent"
+	}
+	return "unknown"
+}
+
+// Classifier classifies an error, returning
+// Unknown for any error it doesn't recognize
+// so other Classifiers get a say
+type Classifier func(err error) Class
+
+// classifiers is replaced wholesale (under
+// registerMu) rather than modified, so
+// Classify can load it without locking
+var (
+	registerMu  sync.Mutex
+	classifiers atomic.Pointer[[]Classifier]
+)
+
+// RegisterClassifier adds c to the classifiers
+// used by Classify, after any already registered.
+// It is meant to be called during initialization
+func RegisterClassifier(c Classifier) {
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	var cs []Classifier
+	if old := classifiers.Load(); old != nil {
+		cs = slices.Clone(*old)
+	}
+
+	cs = append(cs, c)
+	classifiers.Store(&cs)
+}
+
+// Classify returns the first Class other than
+// Unknown that a registered Classifier
+// returns for err, or Unknown if none do.
+// It never locks, so any number of
+// goroutines can classify at once
+func Classify(err error) Class {
+	cs := classifiers.Load()
+	if cs == nil {
+		return Unknown
+	}
+
+	return classify(err, *cs)
+}
+
+func classify(err error, cs []Classifier) Class {
+	for _, c := range cs {
+		if class := c(err); class != Unknown {
+			return class
+		}
+	}
+	return Unknown
+}
+
+// RetryIf composes cs into a predicate for
+// WithRetryIf (or RetryPolicy.RetryIf) that
+// retries any error they don't classify as
+// Permanent, asking each in turn. If no cs are
+// given, the registered Classifiers are used
+func RetryIf(cs ...Classifier) func(err error) bool {
+	return func(err error) bool {
+		if len(cs) == 0 {
+			return Classify(err) != Permanent
+		}
+		return classify(err, cs) != Permanent
+	}
+}
+
+// WithClassifiers is WithRetryIf(RetryIf(cs...))
+func WithClassifiers(cs ...Classifier) TryOption {
+	return WithRetryIf(RetryIf(cs...))
+}
+
+// ContextClassifier classifies a canceled
+// or expired context as Permanent, since
+// retrying once it is done can't succeed
+func ContextClassifier(err error) Class {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return Permanent
+	}
+	return Unknown
+}
+
+// TimeoutClassifier classifies network
+// timeouts as Retryable
+func TimeoutClassifier(err error) Class {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return Retryable
+	}
+	return Unknown
+}
+
+// HTTPStatusClassifier classifies errors carrying
+// an HTTP status code (by implementing
+// StatusCode() int) as Retryable for 408, 429
+// and any 5xx other than 501, and Permanent for
+// any other 4xx or 5xx code
+func HTTPStatusClassifier(err error) Class {
+	var sc interface{ StatusCode() int }
+	if !errors.As(err, &sc) {
+		return Unknown
+	}
+
+	switch code := sc.StatusCode(); {
+	case code == http.StatusRequestTimeout,
+		code == http.StatusTooManyRequests:
+		return Retryable
+	case code == http.StatusNotImplemented:
+		return Permanent
+	case code >= 500:
+		return Retryable
+	case code >= 400:
+		return Permanent
+	}
+
+	return Unknown
+}
+
+// awsThrottlingCodes are the error codes
+// AWS services use for throttling
+var awsThrottlingCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"ThrottledException":                     true,
+	"RequestThrottledException":              true,
+	"TooManyRequestsException":               true,
+	"ProvisionedThroughputExceededException": true,
+	"TransactionInProgressException":         true,
+	"RequestLimitExceeded":                   true,
+	"BandwidthLimitExceeded":                 true,
+	"LimitExceededException":                 true,
+	"RequestThrottled":                       true,
+	"SlowDown":                               true,
+	"PriorRequestNotComplete":                true,
+	"EC2ThrottledException":                  true,
+}
+
+// AWSThrottlingClassifier classifies errors
+// carrying one of AWS's throttling error codes
+// (by implementing ErrorCode() string, as the
+// AWS SDK's smithy.APIError does) as Retryable,
+// without depending on the SDK
+func AWSThrottlingClassifier(err error) Class {
+	var ec interface{ ErrorCode() string }
+	if errors.As(err, &ec) && awsThrottlingCodes[ec.ErrorCode()] {
+		return Retryable
+	}
+	return Unknown
+}
@@ -0,0 +1,122 @@
+package trier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type statusErr int
+
+func (e statusErr) Error() string {
+	return http.StatusText(int(e))
+}
+
+func (e statusErr) StatusCode() int {
+	return int(e)
+}
+
+type apiErr string
+
+func (e apiErr) Error() string {
+	return string(e)
+}
+
+func (e apiErr) ErrorCode() string {
+	return string(e)
+}
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+func TestContextClassifier(t *testing.T) {
+	// Assert
+	assert.Equal(t, Permanent, ContextClassifier(context.Canceled))
+	assert.Equal(t, Permanent, ContextClassifier(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	assert.Equal(t, Unknown, ContextClassifier(fail()))
+}
+
+func TestTimeoutClassifier(t *testing.T) {
+	// Assert
+	assert.Equal(t, Retryable, TimeoutClassifier(timeoutErr{}))
+	assert.Equal(t, Unknown, TimeoutClassifier(fail()))
+}
+
+func TestHTTPStatusClassifier(t *testing.T) {
+	// Assert
+	assert.Equal(t, Retryable, HTTPStatusClassifier(statusErr(http.StatusTooManyRequests)))
+	assert.Equal(t, Retryable, HTTPStatusClassifier(statusErr(http.StatusServiceUnavailable)))
+	assert.Equal(t, Permanent, HTTPStatusClassifier(statusErr(http.StatusNotFound)))
+	assert.Equal(t, Permanent, HTTPStatusClassifier(statusErr(http.StatusNotImplemented)))
+	assert.Equal(t, Unknown, HTTPStatusClassifier(statusErr(http.StatusOK)))
+	assert.Equal(t, Unknown, HTTPStatusClassifier(fail()))
+}
+
+func TestAWSThrottlingClassifier(t *testing.T) {
+	// Assert
+	assert.Equal(t, Retryable, AWSThrottlingClassifier(apiErr("ThrottlingException")))
+	assert.Equal(t, Unknown, AWSThrottlingClassifier(apiErr("AccessDenied")))
+	assert.Equal(t, Unknown, AWSThrottlingClassifier(fail()))
+}
+
+func TestRetryIf(t *testing.T) {
+	// Arrange
+	retryIf := RetryIf(ContextClassifier, HTTPStatusClassifier)
+
+	// Assert
+	assert.True(t, retryIf(statusErr(http.StatusBadGateway)))
+	assert.True(t, retryIf(fail()))
+	assert.False(t, retryIf(statusErr(http.StatusBadRequest)))
+	assert.False(t, retryIf(context.Canceled))
+}
+
+func TestRegisterClassifier(t *testing.T) {
+	// Arrange
+	errPoison := errors.New("poison")
+
+	RegisterClassifier(func(err error) Class {
+		if errors.Is(err, errPoison) {
+			return Permanent
+		}
+		return Unknown
+	})
+
+	// Act
+	class := Classify(fmt.Errorf("wrapped: %w", errPoison))
+
+	// Assert
+	assert.Equal(t, Permanent, class)
+	assert.Equal(t, Unknown, Classify(fail()))
+	assert.False(t, RetryIf()(errPoison))
+}
+
+func TestTrierTryWithClassifiers(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		calls++
+		return statusErr(http.StatusForbidden)
+	}, WithRetry(3), WithClassifiers(HTTPStatusClassifier))
+
+	// Assert
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "Forbidden", tr.Err().Error())
+}
+
+func TestClassString(t *testing.T) {
+	// Assert
+	assert.Equal(t, "unknown", Unknown.String())
+	assert.Equal(t, "retryable", Retryable.String())
+	assert.Equal(t, "permanent", Permanent.String())
+}
@@ -0,0 +1,30 @@
+package trier
+
+import "errors"
+
+// CodeMapping maps any error matching Err (as
+// reported by errors.Is) to Code, such as an
+// HTTP status or a process exit code
+type CodeMapping struct {
+	Err  error
+	Code int
+}
+
+// MapCode returns the Code of the first of
+// mappings whose Err matches the Trier's error,
+// or fallback if none of them do. If the Trier
+// doesn't hold an error, zero is returned
+func (t *Trier) MapCode(fallback int, mappings ...CodeMapping) int {
+	err := t.Err()
+	if err == nil {
+		return 0
+	}
+
+	for _, m := range mappings {
+		if errors.Is(err, m.Err) {
+			return m.Code
+		}
+	}
+
+	return fallback
+}
@@ -0,0 +1,64 @@
+package trier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errNotFound = errors.New("not found")
+	errInvalid  = errors.New("invalid")
+)
+
+var codes = []CodeMapping{
+	{Err: errNotFound, Code: 404},
+	{Err: errInvalid, Code: 400},
+}
+
+func TestTrierMapCode(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(func() error {
+		return errInvalid
+	})
+
+	// Assert
+	assert.Equal(t, 400, tr.MapCode(500, codes...))
+}
+
+func TestTrierMapCodeFirstMatch(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.Record(errInvalid).Record(errNotFound)
+
+	// Assert
+	assert.Equal(t, 404, tr.MapCode(500, codes...))
+}
+
+func TestTrierMapCodeFallback(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(fail)
+
+	// Assert
+	assert.Equal(t, 500, tr.MapCode(500, codes...))
+}
+
+func TestTrierMapCodeNoError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(pass)
+
+	// Assert
+	assert.Equal(t, 0, tr.MapCode(500, codes...))
+}
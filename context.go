@@ -0,0 +1,37 @@
+package trier
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying t,
+// so a request-scoped Trier installed by, for
+// example, middleware can be retrieved with
+// FromContext by any code ctx is passed to.
+// A Trier isn't safe for concurrent use, so
+// code recording into it from several
+// goroutines must synchronize itself
+func NewContext(ctx context.Context, t *Trier) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the *Trier carried
+// by ctx, if NewContext was used to add one
+func FromContext(ctx context.Context) (*Trier, bool) {
+	t, ok := ctx.Value(contextKey{}).(*Trier)
+	return t, ok
+}
+
+// Record records err, if it isn't nil,
+// joining it with any previous error just
+// as TryJoin would. It lets code that
+// already has an error (such as code deep
+// in a call stack using FromContext) record
+// it without wrapping it in a function.
+// Record isn't a step, so it doesn't take
+// up a step index, and err is recorded
+// even once a WithChainTimeout has passed
+func (t *Trier) Record(err error) *Trier {
+	t.record(err)
+	return t
+}
@@ -0,0 +1,95 @@
+package trier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContext(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	ctx := NewContext(context.Background(), tr)
+	got, ok := FromContext(ctx)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Same(t, tr, got)
+}
+
+func TestFromContextMissing(t *testing.T) {
+	// Act
+	got, ok := FromContext(context.Background())
+
+	// Assert
+	assert.False(t, ok)
+	assert.Nil(t, got)
+}
+
+func TestTrierRecord(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	deep := func(ctx context.Context) {
+		if tr, ok := FromContext(ctx); ok {
+			tr.Record(fail())
+		}
+	}
+
+	// Act
+	deep(NewContext(context.Background(), tr))
+	tr.Record(nil)
+
+	// Assert
+	assert.Equal(t, "failed fail", tr.Err().Error())
+}
+
+func TestTrierRecordJoins(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.Record(fail()).Record(failIfString("hi"))
+
+	// Assert
+	assert.Equal(t, "failedIfString\nfailed fail", tr.Err().Error())
+}
+
+func TestTrierRecordNotStep(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(pass).
+		Record(nil).
+		Record(nil).
+		TryWith(passOrFail, WithArgs(true), WithStepName("save"))
+
+	// Assert
+	var se *StepError
+	assert.ErrorAs(t, tr.Err(), &se)
+	assert.Equal(t, 1, se.Index)
+}
+
+func TestTrierRecordChainTimeout(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithChainTimeout(time.Millisecond))
+
+	time.Sleep(5 * time.Millisecond)
+
+	tr.TryFunc(pass)
+
+	late := errors.New("late")
+
+	// Act
+	tr.Record(late)
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), late)
+	assert.ErrorAs(t, tr.Err(), new(*ChainTimeoutError))
+}
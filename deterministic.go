@@ -0,0 +1,62 @@
+package trier
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand/v2"
+	"sync"
+)
+
+// WithDeterministic makes everything the Trier
+// randomizes reproducible from seed, for fuzz
+// tests and simulations of how chains behave
+// as steps fail: the jitter added by WithJitter
+// and the idempotency keys TryCtx hands out.
+// Steps run concurrently, by TryBatchParallel
+// or a Chain's ExecuteParallel, still draw
+// from the same seed, but in whatever order
+// they happen to run, so give them a limit
+// of 1 to have them run in order too
+func WithDeterministic(seed uint64) Option {
+	return func(t *Trier) {
+		t.rnd = &source{r: rand.New(rand.NewPCG(seed, seed))}
+	}
+}
+
+// source is the seeded randomness set
+// by WithDeterministic, which forks of
+// a Trier may share between goroutines.
+// A nil *source draws from math/rand
+type source struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// Float64 returns a number in [0.0, 1.0)
+func (s *source) Float64() float64 {
+	if s == nil {
+		return rand.Float64()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.r.Float64()
+}
+
+// key returns a new idempotency key,
+// like NewIdempotencyKey
+func (s *source) key() string {
+	if s == nil {
+		return NewIdempotencyKey()
+	}
+
+	b := make([]byte, 16)
+
+	s.mu.Lock()
+	binary.LittleEndian.PutUint64(b, s.r.Uint64())
+	binary.LittleEndian.PutUint64(b[8:], s.r.Uint64())
+	s.mu.Unlock()
+
+	return hex.EncodeToString(b)
+}
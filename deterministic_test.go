@@ -0,0 +1,120 @@
+package trier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func jittered(seed uint64) []time.Duration {
+	tr := NewTrier(WithDeterministic(seed))
+
+	c := newTryConfig([]TryOption{
+		WithBackoff(func(i int) time.Duration {
+			return time.Second
+		}),
+		WithJitter(0.5),
+	})
+
+	delays := make([]time.Duration, 5)
+	for i := range delays {
+		delays[i] = c.delay(i, fail(), tr.rnd)
+	}
+
+	return delays
+}
+
+func TestWithDeterministicJitter(t *testing.T) {
+	// Arrange
+	first := jittered(42)
+
+	// Act
+	second := jittered(42)
+	other := jittered(7)
+
+	// Assert
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+}
+
+func keys(seed uint64) []string {
+	tr := NewTrier(WithDeterministic(seed))
+
+	var keys []string
+
+	for range 3 {
+		tr.TryCtx(context.Background(), func(ctx context.Context) error {
+			keys = append(keys, IdempotencyKey(ctx))
+			return nil
+		})
+	}
+
+	return keys
+}
+
+func TestWithDeterministicIdempotencyKeys(t *testing.T) {
+	// Arrange
+	first := keys(42)
+
+	// Act
+	second := keys(42)
+
+	// Assert
+	assert.Equal(t, first, second)
+	assert.Len(t, first[0], 32)
+	assert.NotEqual(t, first[0], first[1])
+}
+
+func TestWithDeterministicTryBatchParallel(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithDeterministic(42))
+
+	var order []int
+
+	fns := make([]func() error, 20)
+	for i := range fns {
+		fns[i] = func() error {
+			order = append(order, i)
+			return nil
+		}
+	}
+
+	// Act
+	tr.TryBatchParallel(1, fns)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}, order)
+}
+
+func TestWithDeterministicTryBatchParallelConcurrent(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithDeterministic(42))
+
+	// each fn waits for the other to
+	// start, so they only both succeed
+	// if they're run at the same time
+	started := []chan struct{}{make(chan struct{}), make(chan struct{})}
+
+	wait := func(i int) func() error {
+		return func() error {
+			close(started[i])
+
+			select {
+			case <-started[1-i]:
+				return nil
+			case <-time.After(time.Second):
+				return errors.New("run alone")
+			}
+		}
+	}
+
+	// Act
+	tr.TryBatchParallel(2, []func() error{wait(0), wait(1)})
+
+	// Assert
+	assert.Nil(t, tr.Err())
+}
@@ -0,0 +1,37 @@
+package trier
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// osExit and stderr are swapped out in tests
+var (
+	osExit           = os.Exit
+	stderr io.Writer = os.Stderr
+)
+
+// ExitCode maps the Trier's error to a process
+// exit code like MapCode, falling back to one
+// for errors none of mappings match
+func (t *Trier) ExitCode(mappings ...CodeMapping) int {
+	return t.MapCode(1, mappings...)
+}
+
+// Run calls main with the Trier, then, if it
+// holds an error, prints it to stderr with every
+// error annotated with the step it came from,
+// and exits the process with the code ExitCode
+// maps it to (or zero if there's no error)
+func (t *Trier) Run(main func(t *Trier), mappings ...CodeMapping) {
+	t.annotate = true
+
+	main(t)
+
+	if err := t.Err(); err != nil {
+		fmt.Fprintln(stderr, err)
+	}
+
+	osExit(t.ExitCode(mappings...))
+}
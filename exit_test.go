@@ -0,0 +1,95 @@
+package trier
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// exitCapture swaps out osExit and stderr
+// for the duration of a test
+func exitCapture(t *testing.T) (*int, *bytes.Buffer) {
+	code := -1
+	out := &bytes.Buffer{}
+
+	oldExit, oldStderr := osExit, stderr
+	t.Cleanup(func() {
+		osExit, stderr = oldExit, oldStderr
+	})
+
+	osExit = func(c int) {
+		code = c
+	}
+	stderr = out
+
+	return &code, out
+}
+
+func TestTrierExitCode(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.Record(errNotFound)
+
+	// Assert
+	assert.Equal(t, 404, tr.ExitCode(codes...))
+}
+
+func TestTrierExitCodeFallback(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(fail)
+
+	// Assert
+	assert.Equal(t, 1, tr.ExitCode(codes...))
+}
+
+func TestTrierExitCodeNoError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(pass)
+
+	// Assert
+	assert.Equal(t, 0, tr.ExitCode(codes...))
+}
+
+func TestTrierRun(t *testing.T) {
+	// Arrange
+	code, out := exitCapture(t)
+
+	tr := NewTrier(WithName("deploy"))
+
+	// Act
+	tr.Run(func(tr *Trier) {
+		tr.TryFunc(pass).
+			TryFunc(func() error {
+				return errInvalid
+			}, WithStepName("validate"))
+	}, codes...)
+
+	// Assert
+	assert.Equal(t, 400, *code)
+	assert.Equal(t, "deploy: validate: invalid\n", out.String())
+}
+
+func TestTrierRunSuccess(t *testing.T) {
+	// Arrange
+	code, out := exitCapture(t)
+
+	tr := NewTrier()
+
+	// Act
+	tr.Run(func(tr *Trier) {
+		tr.TryFunc(pass)
+	})
+
+	// Assert
+	assert.Equal(t, 0, *code)
+	assert.Empty(t, out.String())
+}
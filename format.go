@@ -0,0 +1,111 @@
+package trier
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format makes %v and %s print the same
+// message as Error, while %+v breaks it
+// down with one error per line, newest
+// first as Err joins them, along with
+// the step, attempts and repeats
+// recorded for each of them. Errors
+// that format themselves with %+v (such
+// as ones carrying a stack trace) still
+// do so
+func (t *Trier) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		t.formatDetail(s)
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", t.Error())
+	default:
+		io.WriteString(s, t.Error())
+	}
+}
+
+// formatDetail writes the breakdown
+// printed by Format for %+v
+func (t *Trier) formatDetail(w io.Writer) {
+	name := t.name
+	if name == "" {
+		name = "trier"
+	}
+
+	switch len(t.errs) {
+	case 0:
+		fmt.Fprintf(w, "%s: no errors", name)
+	case 1:
+		fmt.Fprintf(w, "%s: 1 error", name)
+	default:
+		fmt.Fprintf(w, "%s: %d errors", name, len(t.errs))
+	}
+
+	if t.dropped > 0 {
+		fmt.Fprintf(w, "\n    (%d more errors dropped)", t.dropped)
+	}
+
+	for i := len(t.errs) - 1; i >= 0; i-- {
+		formatErr(w, t.errs[i], "\n    ")
+	}
+}
+
+// formatErr writes err on its own line,
+// prefixed by what the Trier recorded
+// about it, with the errors inside a
+// joined error on lines of their own
+func formatErr(w io.Writer, err error, indent string) {
+	err, details := describe(err)
+
+	io.WriteString(w, indent)
+
+	if len(details) != 0 {
+		io.WriteString(w, strings.Join(details, ", "))
+		io.WriteString(w, ": ")
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		io.WriteString(w, "joined")
+
+		for _, err := range joined.Unwrap() {
+			formatErr(w, err, indent+"    ")
+		}
+
+		return
+	}
+
+	fmt.Fprintf(w, "%+v", err)
+}
+
+// describe unwraps the errors the Trier
+// wraps a recorded error in, returning the
+// error inside along with what they tell
+func describe(err error) (error, []string) {
+	var details []string
+
+	for {
+		switch e := err.(type) {
+		case *StepError:
+			if e.Name == "" {
+				details = append(details, fmt.Sprintf("step %d", e.Index))
+			} else {
+				details = append(details, fmt.Sprintf("step %d (%s)", e.Index, e.Name))
+			}
+			err = e.Err
+		case *RetryError:
+			if e.Limit > 0 {
+				details = append(details, fmt.Sprintf("%d/%d attempts", e.Attempts, e.Limit))
+			} else {
+				details = append(details, fmt.Sprintf("%d attempts", e.Attempts))
+			}
+			err = e.Err
+		case *RepeatedError:
+			details = append(details, fmt.Sprintf("%d times", e.Count))
+			err = e.Err
+		default:
+			return err, details
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package trier
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stackErr struct{}
+
+func (stackErr) Error() string {
+	return "stack err"
+}
+
+func (e stackErr) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprint(s, "stack err\n\tmain.go:12")
+		return
+	}
+	fmt.Fprint(s, e.Error())
+}
+
+func TestTrierFormat(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithName("loader"))
+
+	// Act
+	tr.Try(passOrFail, true)
+
+	// Assert
+	assert.Equal(t, "loader: failed passOrFail", fmt.Sprintf("%v", tr))
+	assert.Equal(t, "loader: failed passOrFail", fmt.Sprintf("%s", tr))
+	assert.Equal(t, `"loader: failed passOrFail"`, fmt.Sprintf("%q", tr))
+	assert.Equal(t, "wrapped: loader: failed passOrFail", fmt.Errorf("wrapped: %w", tr).Error())
+}
+
+func TestTrierFormatDetail(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	attempt := 0
+
+	// Act
+	tr.TryRetry(3, func(args ...any) error {
+		attempt++
+		if attempt == 1 {
+			return errors.New("refused")
+		}
+		return errors.New("timeout")
+	})
+
+	tr.TryWith(passOrFail, WithArgs(true), WithStepName("save"), WithJoin()).
+		TryJoinFunc(func() error {
+			return stackErr{}
+		})
+
+	// Assert
+	assert.Equal(t, `trier: 3 errors
+    stack err
+	main.go:12
+    step 1 (save): failed passOrFail
+    3/3 attempts: joined
+        2 times: timeout
+        refused`, fmt.Sprintf("%+v", tr))
+}
+
+func TestTrierFormatDetailEmpty(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithName("loader"), WithErrorRetention(1))
+
+	// Act
+	empty := fmt.Sprintf("%+v", tr)
+
+	tr.TryJoin(passOrFail, true).
+		TryJoin(passOrFail, true)
+
+	// Assert
+	assert.Equal(t, "loader: no errors", empty)
+	assert.Equal(t, "loader: 1 error\n    (1 more errors dropped)\n    failed passOrFail", fmt.Sprintf("%+v", tr))
+}
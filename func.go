@@ -0,0 +1,84 @@
+package trier
+
+import "time"
+
+// TryFunc is like TryWith, but accepts a plain
+// func() error so it can be passed directly.
+// Like every Func method, fn is called as is
+// rather than adapted to take args, so trying
+// it without opts doesn't allocate unless
+// it fails
+func (t *Trier) TryFunc(fn func() error, opts ...TryOption) *Trier {
+	if len(opts) == 0 {
+		return t.tryFast(nil, tryConfig{limit: 1, plain: fn})
+	}
+
+	c := newTryConfig(opts)
+	c.plain = fn
+
+	t.try(nil, &c)
+
+	return t
+}
+
+// TryIfErrFunc is like TryIfErr, but accepts
+// a plain func() error
+func (t *Trier) TryIfErrFunc(errFn func(err error) error, fn func() error) *Trier {
+	return t.tryFast(nil, tryConfig{limit: 1, plain: fn, errFn: errFn, guards: guardErrFn})
+}
+
+// TryRetryFunc is like TryRetry, but accepts
+// a plain func() error
+func (t *Trier) TryRetryFunc(limit int, fn func() error) *Trier {
+	return t.tryFast(nil, tryConfig{limit: limit, plain: fn})
+}
+
+// TryRetryIfErrFunc is like TryRetryIfErr,
+// but accepts a plain func() error
+func (t *Trier) TryRetryIfErrFunc(limit int, errFn func(err error) error, fn func() error) *Trier {
+	return t.tryFast(nil, tryConfig{limit: limit, plain: fn, errFn: errFn, guards: guardErrFn})
+}
+
+// TryRetryBackoffFunc is like TryRetryBackoff,
+// but accepts a plain func() error
+func (t *Trier) TryRetryBackoffFunc(limit int, backoff func(i int) time.Duration, fn func() error) *Trier {
+	return t.tryFast(nil, tryConfig{limit: limit, plain: fn, backoff: backoff, guards: guardBackoff})
+}
+
+// TryRetryBackoffIfErrFunc is like
+// TryRetryBackoffIfErr, but accepts
+// a plain func() error
+func (t *Trier) TryRetryBackoffIfErrFunc(limit int, errFn func(err error) error, backoff func(i int) time.Duration, fn func() error) *Trier {
+	return t.tryFast(nil, tryConfig{limit: limit, plain: fn, backoff: backoff, errFn: errFn, guards: guardBackoff | guardErrFn})
+}
+
+// TryJoinFunc is like TryJoin, but accepts
+// a plain func() error
+func (t *Trier) TryJoinFunc(fn func() error) *Trier {
+	return t.tryFast(nil, tryConfig{limit: 1, plain: fn, join: true})
+}
+
+// Capture turns a value-returning fn into a step
+// that can be passed to any Try method. Each time
+// the step is tried, fn is called and, if it
+// doesn't return an error, its result is stored
+// into dst. On error, dst is left untouched.
+// If fn or dst is nil, Capture returns nil, so
+// the step is guarded against like any other
+// nil fn, recording ErrNilFunc
+func Capture[T any](dst *T, fn func() (T, error)) func(args ...any) error {
+	if dst == nil || fn == nil {
+		return nil
+	}
+
+	return func(args ...any) error {
+		v, err := fn()
+		if err != nil {
+			return err
+		}
+
+		*dst = v
+
+		return nil
+	}
+}
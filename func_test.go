@@ -0,0 +1,198 @@
+package trier
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pass() error {
+	return nil
+}
+
+func fail() error {
+	return errors.New("failed fail")
+}
+
+func TestTrierTryFunc(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(pass)
+
+	// Assert
+	assert.Empty(t, tr.errs)
+}
+
+func TestTrierTryFuncError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(pass).
+		TryFunc(fail).
+		Try(passOrFail, true)
+
+	// Assert
+	assert.Equal(t, "failed fail", tr.Err().Error())
+}
+
+func TestTrierTryIfErrFunc(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryIfErrFunc(func(err error) error {
+		return errors.New("wrapped: " + err.Error())
+	}, fail)
+
+	// Assert
+	assert.Equal(t, "wrapped: failed fail", tr.Err().Error())
+}
+
+func TestTrierTryRetryFunc(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	// Act
+	tr.TryRetryFunc(0, func() error {
+		calls++
+		if calls < 3 {
+			return fail()
+		}
+		return nil
+	})
+
+	// Assert
+	assert.Empty(t, tr.errs)
+	assert.Equal(t, 3, calls)
+}
+
+func TestTrierTryRetryBackoffFunc(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	// Act
+	tr.TryRetryBackoffFunc(3, func(i int) time.Duration {
+		return time.Millisecond
+	}, func() error {
+		calls++
+		return nil
+	})
+
+	// Assert
+	assert.Empty(t, tr.errs)
+	assert.Equal(t, 1, calls)
+}
+
+func TestTrierTryJoinFunc(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(pass).
+		TryJoinFunc(fail)
+
+	// Assert
+	assert.Equal(t, "failed fail", tr.Err().Error())
+}
+
+func TestCapture(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	var x string
+
+	// Act
+	tr.Try(Capture(&x, func() (string, error) {
+		return "hello", nil
+	}))
+
+	// Assert
+	assert.Empty(t, tr.errs)
+	assert.Equal(t, "hello", x)
+}
+
+func TestCaptureError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	x := "untouched"
+
+	// Act
+	tr.Try(Capture(&x, func() (string, error) {
+		return "hello", fail()
+	}))
+
+	// Assert
+	assert.Equal(t, "failed fail", tr.Err().Error())
+	assert.Equal(t, "untouched", x)
+}
+
+func TestCaptureNil(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	var x int
+
+	// Act
+	tr.TryJoin(Capture[int](&x, nil)).
+		TryJoin(Capture(nil, func() (int, error) {
+			return 1, nil
+		}))
+
+	// Assert
+	assert.Equal(t, "step 1: try attempted with nil func\nstep 0: try attempted with nil func", tr.Err().Error())
+}
+
+func TestCaptureRetry(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	var x int
+
+	// Act
+	tr.TryRetry(0, Capture(&x, func() (int, error) {
+		calls++
+		if calls < 2 {
+			return 0, fail()
+		}
+		return calls, nil
+	}))
+
+	// Assert
+	assert.Empty(t, tr.errs)
+	assert.Equal(t, 2, x)
+}
+
+func TestTrierTryFuncNoAllocs(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	errFn := func(err error) error {
+		return err
+	}
+
+	// Act
+	allocs := testing.AllocsPerRun(100, func() {
+		tr.TryFunc(pass).
+			TryIfErrFunc(errFn, pass).
+			TryRetryFunc(3, pass).
+			TryRetryIfErrFunc(3, errFn, pass).
+			TryJoinFunc(pass).
+			TryWith(nop)
+	})
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Zero(t, allocs)
+}
@@ -0,0 +1,30 @@
+package trier
+
+import "errors"
+
+// ErrNilGroup is recorded by FromGroup
+// in place of waiting on a nil group
+var ErrNilGroup = errors.New("wait attempted on nil group")
+
+// Waiter is implemented by anything that
+// can be waited on for an error, such as
+// an *errgroup.Group or a *sync.WaitGroup
+// wrapped to return one
+type Waiter interface {
+	Wait() error
+}
+
+// FromGroup waits for g and records any error
+// it returns, just as Record would. g is
+// waited on even if the Trier already holds
+// an error, since code moved over from
+// errgroup relies on Wait as a barrier
+// for the goroutines it started. If g is
+// nil, ErrNilGroup is recorded instead
+func (t *Trier) FromGroup(g Waiter) *Trier {
+	if g == nil {
+		return t.Record(ErrNilGroup)
+	}
+
+	return t.Record(g.Wait())
+}
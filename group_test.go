@@ -0,0 +1,53 @@
+package trier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type waiterFunc func() error
+
+func (w waiterFunc) Wait() error {
+	return w()
+}
+
+func TestTrierFromGroup(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.FromGroup(waiterFunc(fail))
+
+	// Assert
+	assert.Equal(t, "failed fail", tr.Err().Error())
+}
+
+func TestTrierFromGroupPreviousError(t *testing.T) {
+	// Arrange
+	tr := NewTrier().TryFunc(fail)
+
+	waited := false
+
+	// Act
+	tr.FromGroup(waiterFunc(func() error {
+		waited = true
+		return errors.New("group failed")
+	}))
+
+	// Assert
+	assert.True(t, waited)
+	assert.Equal(t, "group failed\nfailed fail", tr.Err().Error())
+}
+
+func TestTrierFromGroupNil(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.FromGroup(nil)
+
+	// Assert
+	assert.Equal(t, ErrNilGroup, tr.Err())
+}
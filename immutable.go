@@ -0,0 +1,180 @@
+package trier
+
+import (
+	"slices"
+	"time"
+)
+
+// ImmutableTrier is a value-semantics Trier.
+// Rather than mutating shared state, every
+// Try method returns a new ImmutableTrier,
+// leaving the one it was called on untouched.
+// This makes it safe to keep an ImmutableTrier
+// around as a template and branch several
+// chains off of it without them aliasing
+// each other's errors. Since it's a plain
+// value, a short-lived chain of one can live
+// entirely on the stack, and trying functions
+// that succeed doesn't allocate
+type ImmutableTrier struct {
+	t Trier
+}
+
+// NewImmutableTrier creates a new ImmutableTrier
+// configured with any provided opts. Applying
+// opts allocates, but the zero ImmutableTrier
+// (which this returns without opts) doesn't
+func NewImmutableTrier(opts ...Option) ImmutableTrier {
+	if len(opts) == 0 {
+		return ImmutableTrier{}
+	}
+
+	return NewTrier(opts...).Immutable()
+}
+
+// Immutable returns an ImmutableTrier holding
+// a snapshot of t's current state. Later
+// changes to t are not seen by the snapshot
+func (t *Trier) Immutable() ImmutableTrier {
+	it := ImmutableTrier{t: *t}
+	it.t.detach()
+
+	return it
+}
+
+// clone returns a copy of t that shares
+// no mutable state with it
+func (t *Trier) clone() *Trier {
+	c := *t
+	c.detach()
+
+	return &c
+}
+
+// detach clips t's errs, so that appending
+// to them copies them rather than writing
+// into storage shared with another copy
+// of the Trier. Errors are never modified
+// in place, so that's all copies share
+func (t *Trier) detach() {
+	t.errs = slices.Clip(t.errs)
+	t.results = slices.Clip(t.results)
+}
+
+// Try is like (*Trier).Try, but returns
+// a new ImmutableTrier
+func (it ImmutableTrier) Try(fn func(args ...any) error, args ...any) ImmutableTrier {
+	it.t.detach()
+	it.t.Try(fn, args...)
+
+	return it
+}
+
+// TryWith is like (*Trier).TryWith, but
+// returns a new ImmutableTrier
+func (it ImmutableTrier) TryWith(fn func(args ...any) error, opts ...TryOption) ImmutableTrier {
+	it.t.detach()
+	it.t.TryWith(fn, opts...)
+
+	return it
+}
+
+// TryFunc is like (*Trier).TryFunc, but
+// returns a new ImmutableTrier
+func (it ImmutableTrier) TryFunc(fn func() error, opts ...TryOption) ImmutableTrier {
+	it.t.detach()
+	it.t.TryFunc(fn, opts...)
+
+	return it
+}
+
+// TryIfErr is like (*Trier).TryIfErr, but
+// returns a new ImmutableTrier
+func (it ImmutableTrier) TryIfErr(errFn func(err error) error, fn func(args ...any) error, args ...any) ImmutableTrier {
+	it.t.detach()
+	it.t.TryIfErr(errFn, fn, args...)
+
+	return it
+}
+
+// TryRetry is like (*Trier).TryRetry, but
+// returns a new ImmutableTrier
+func (it ImmutableTrier) TryRetry(limit int, fn func(args ...any) error, args ...any) ImmutableTrier {
+	it.t.detach()
+	it.t.TryRetry(limit, fn, args...)
+
+	return it
+}
+
+// TryRetryIfErr is like (*Trier).TryRetryIfErr,
+// but returns a new ImmutableTrier
+func (it ImmutableTrier) TryRetryIfErr(limit int, errFn func(err error) error, fn func(args ...any) error, args ...any) ImmutableTrier {
+	it.t.detach()
+	it.t.TryRetryIfErr(limit, errFn, fn, args...)
+
+	return it
+}
+
+// TryRetryBackoff is like (*Trier).TryRetryBackoff,
+// but returns a new ImmutableTrier
+func (it ImmutableTrier) TryRetryBackoff(limit int, backoff func(i int) time.Duration, fn func(args ...any) error, args ...any) ImmutableTrier {
+	it.t.detach()
+	it.t.TryRetryBackoff(limit, backoff, fn, args...)
+
+	return it
+}
+
+// TryRetryBackoffIfErr is like
+// (*Trier).TryRetryBackoffIfErr, but
+// returns a new ImmutableTrier
+func (it ImmutableTrier) TryRetryBackoffIfErr(limit int, errFn func(err error) error, backoff func(i int) time.Duration, fn func(args ...any) error, args ...any) ImmutableTrier {
+	it.t.detach()
+	it.t.TryRetryBackoffIfErr(limit, errFn, backoff, fn, args...)
+
+	return it
+}
+
+// TryJoin is like (*Trier).TryJoin, but
+// returns a new ImmutableTrier
+func (it ImmutableTrier) TryJoin(fn func(args ...any) error, args ...any) ImmutableTrier {
+	it.t.detach()
+	it.t.TryJoin(fn, args...)
+
+	return it
+}
+
+// TryJoinFunc is like (*Trier).TryJoinFunc,
+// but returns a new ImmutableTrier
+func (it ImmutableTrier) TryJoinFunc(fn func() error) ImmutableTrier {
+	it.t.detach()
+	it.t.TryJoinFunc(fn)
+
+	return it
+}
+
+// Nil returns a new ImmutableTrier
+// with any error nilled out
+func (it ImmutableTrier) Nil() ImmutableTrier {
+	it.t.detach()
+	it.t.Nil()
+
+	return it
+}
+
+// Name returns the name given to the
+// ImmutableTrier with WithName, if any
+func (it ImmutableTrier) Name() string {
+	return it.t.Name()
+}
+
+// Err returns the error held by the
+// ImmutableTrier, or nil if there is none
+func (it ImmutableTrier) Err() error {
+	return it.t.Err()
+}
+
+// Trier returns a new mutable *Trier
+// starting from the ImmutableTrier's state
+func (it ImmutableTrier) Trier() *Trier {
+	return it.t.clone()
+}
@@ -0,0 +1,116 @@
+package trier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewImmutableTrier(t *testing.T) {
+	// Act
+	it := NewImmutableTrier()
+
+	// Assert
+	assert.Nil(t, it.Err())
+}
+
+func TestImmutableTrierTry(t *testing.T) {
+	// Arrange
+	it := NewImmutableTrier()
+
+	// Act
+	failed := it.Try(passOrFail, true)
+
+	// Assert
+	assert.Nil(t, it.Err())
+	assert.Equal(t, "failed passOrFail", failed.Err().Error())
+}
+
+func TestImmutableTrierTemplate(t *testing.T) {
+	// Arrange
+	template := NewImmutableTrier(WithName("template")).
+		Try(passOrFail)
+
+	// Act
+	first := template.Try(passOrFail, true)
+	second := template.TryFunc(fail)
+
+	// Assert
+	assert.Nil(t, template.Err())
+	assert.Equal(t, "template: failed passOrFail", first.Err().Error())
+	assert.Equal(t, "template: failed fail", second.Err().Error())
+}
+
+func TestImmutableTrierNoAliasing(t *testing.T) {
+	// Arrange
+	failed := NewImmutableTrier().Try(passOrFail, true)
+
+	// Act
+	joined := failed.TryJoin(failIfString, "hi")
+
+	// Assert
+	assert.Equal(t, "failed passOrFail", failed.Err().Error())
+	assert.ErrorContains(t, joined.Err(), "failedIfString")
+}
+
+func TestImmutableTrierNil(t *testing.T) {
+	// Arrange
+	failed := NewImmutableTrier().Try(passOrFail, true)
+
+	// Act
+	cleared := failed.Nil()
+
+	// Assert
+	assert.NotNil(t, failed.Err())
+	assert.Nil(t, cleared.Err())
+}
+
+func TestTrierImmutableSnapshot(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	it := tr.Immutable()
+	tr.Try(passOrFail, true)
+
+	// Assert
+	assert.Nil(t, it.Err())
+	assert.NotNil(t, it.Trier().Try(passOrFail, true).Err())
+}
+
+func TestImmutableTrierNoAllocs(t *testing.T) {
+	// Arrange
+	var err error
+
+	// Act
+	allocs := testing.AllocsPerRun(100, func() {
+		err = NewImmutableTrier().
+			Try(passOrFail).
+			TryFunc(pass).
+			TryRetry(3, passOrFail).
+			TryJoinFunc(pass).
+			Err()
+	})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Zero(t, allocs)
+}
+
+func TestImmutableTrierBranchesDontAlias(t *testing.T) {
+	// Arrange
+	base := NewImmutableTrier().
+		TryJoin(passOrFail, true).
+		TryJoin(passOrFail, true)
+
+	// Act
+	first := base.TryJoin(failIfString, "first")
+	second := base.TryJoin(func(args ...any) error {
+		return errors.New("second")
+	})
+
+	// Assert
+	assert.EqualError(t, first.Err(), "failedIfString\nfailed passOrFail\nfailed passOrFail")
+	assert.EqualError(t, second.Err(), "second\nfailed passOrFail\nfailed passOrFail")
+}
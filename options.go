@@ -0,0 +1,107 @@
+package trier
+
+import "time"
+
+// Option configures a Trier created with NewTrier
+type Option func(t *Trier)
+
+// WithName labels the Trier with name so any
+// error it returns is prefixed with it, making
+// it possible to tell which chain an error came
+// from when several chains run side by side
+func WithName(name string) Option {
+	return func(t *Trier) {
+		t.name = name
+	}
+}
+
+// WithErrFn passes every error recorded by
+// the Trier through errFn first, so common
+// wrapping (such as adding context or
+// converting to domain errors) only needs
+// configuring once rather than using TryIfErr
+// for every step. If errFn returns nil, the
+// error is dropped
+func WithErrFn(errFn func(err error) error) Option {
+	return func(t *Trier) {
+		t.errFn = errFn
+	}
+}
+
+// WithChainTimeout bounds the total time the
+// Trier spends trying steps, across every
+// step, retry and backoff, to d, measured from
+// when the Trier is created. Once d has passed,
+// the step in flight is failed with a
+// *ChainTimeoutError and no further steps are
+// tried, even by TryJoin
+func WithChainTimeout(d time.Duration) Option {
+	return func(t *Trier) {
+		t.chainTimeout = d
+		t.deadline = time.Now().Add(d)
+		t.guarded = true
+	}
+}
+
+// WithErrorCapacity preallocates room for n
+// errors, for chains expected to collect many
+// of them (such as batch jobs using TryJoin)
+func WithErrorCapacity(n int) Option {
+	return func(t *Trier) {
+		t.errs = make([]error, 0, n)
+	}
+}
+
+// WithErrorRetention keeps only the first n
+// errors recorded by the Trier, counting the
+// rest rather than keeping them, so runs
+// that go badly wrong can't grow without
+// bound. Err reports how many were dropped
+// with a *DroppedError
+func WithErrorRetention(n int) Option {
+	return func(t *Trier) {
+		t.retain = n
+	}
+}
+
+// WithFirstErrorOnly latches the first error
+// recorded by the Trier, ignoring any after
+// it, so errors are never joined together.
+// Functions passed to TryJoin still run, but
+// their errors are dropped once the Trier has
+// failed, and a step that is retried keeps
+// only the error from its final attempt.
+// It suits latency-critical code that doesn't
+// need multi-error reporting
+func WithFirstErrorOnly() Option {
+	return func(t *Trier) {
+		t.firstOnly = true
+	}
+}
+
+// WithStrict makes the Trier panic when a
+// method is called with a nil fn, errFn or
+// backoff (or a limit it can't work with),
+// rather than recording the *StepError it
+// would otherwise, for failing loudly
+// in development
+func WithStrict() Option {
+	return func(t *Trier) {
+		t.strict = true
+	}
+}
+
+// WithStepResults makes the Trier keep a
+// StepResult for every step it tries,
+// whether or not it succeeded, to be read
+// with Steps, so how many attempts a step
+// took to succeed can be checked. ForTest
+// sets it
+func WithStepResults() Option {
+	return func(t *Trier) {
+		t.keepSteps = true
+		// Try's fast path doesn't
+		// keep step results
+		t.guarded = true
+	}
+}
@@ -0,0 +1,294 @@
+package trier
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTrierWithName(t *testing.T) {
+	// Act
+	tr := NewTrier(WithName("user-signup"))
+
+	// Assert
+	assert.Equal(t, "user-signup", tr.Name())
+}
+
+func TestTrierErrWithName(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithName("user-signup"))
+
+	// Act
+	tr.Try(passOrFail, true)
+
+	// Assert
+	assert.Equal(t, "user-signup: failed passOrFail", tr.Err().Error())
+}
+
+func TestTrierErrWithNameUnwraps(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithName("user-signup"))
+
+	errFailed := errors.New("failed")
+
+	// Act
+	tr.Try(func(args ...any) error {
+		return errFailed
+	})
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), errFailed)
+}
+
+func TestTrierErrWithNameNoError(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithName("user-signup"))
+
+	// Act
+	tr.TryJoin(passOrFail)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+}
+
+func TestTrierWithChainTimeout(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithChainTimeout(20 * time.Millisecond))
+
+	var calls atomic.Int32
+
+	// Act
+	tr.TryFunc(pass).
+		TryWith(func(args ...any) error {
+			calls.Add(1)
+			time.Sleep(time.Second)
+			return nil
+		}, WithStepName("slow")).
+		TryJoinFunc(func() error {
+			calls.Add(1)
+			return nil
+		})
+
+	// Assert
+	var timeoutErr *ChainTimeoutError
+	assert.ErrorAs(t, tr.Err(), &timeoutErr)
+	assert.ErrorIs(t, tr.Err(), ErrChainTimeout)
+	assert.Equal(t, 1, timeoutErr.Step)
+	assert.Equal(t, "slow", timeoutErr.Name)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestTrierWithChainTimeoutDuringBackoff(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithChainTimeout(20 * time.Millisecond))
+
+	calls := 0
+
+	// Act
+	start := time.Now()
+	tr.TryWith(func(args ...any) error {
+		calls++
+		return fail()
+	}, WithRetry(5), WithBackoff(func(i int) time.Duration {
+		return time.Second
+	}))
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrChainTimeout)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 1, calls)
+}
+
+func TestTrierWithChainTimeoutNotReached(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithChainTimeout(time.Second))
+
+	// Act
+	tr.TryFunc(pass).TryFunc(pass)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+}
+
+func TestTrierWithChainTimeoutExpired(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithChainTimeout(time.Millisecond))
+
+	calls := 0
+
+	time.Sleep(5 * time.Millisecond)
+
+	// Act
+	tr.TryFunc(func() error {
+		calls++
+		return nil
+	})
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrChainTimeout)
+	assert.Equal(t, 0, calls)
+}
+
+func TestTrierWithErrFn(t *testing.T) {
+	// Arrange
+	errDomain := errors.New("domain error")
+
+	tr := NewTrier(WithErrFn(func(err error) error {
+		return errors.Join(errDomain, err)
+	}))
+
+	// Act
+	tr.TryJoin(passOrFail, true).
+		TryJoinFunc(fail)
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), errDomain)
+	assert.Equal(t, "domain error\nfailed fail\ndomain error\nfailed passOrFail", tr.Err().Error())
+}
+
+func TestTrierWithErrFnDropsError(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithErrFn(func(err error) error {
+		return nil
+	}))
+
+	// Act
+	tr.Try(passOrFail, true)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+}
+
+func TestChainWithErrFnReport(t *testing.T) {
+	// Arrange
+	c := NewChain(WithErrFn(func(err error) error {
+		return errors.New("converted")
+	})).Then(Step{Fn: passOrFail, Args: []any{true}})
+
+	// Act
+	report, err := c.Execute()
+
+	// Assert
+	assert.Equal(t, "converted", err.Error())
+	assert.Equal(t, "converted", report.Steps[0].Err.Error())
+}
+
+func TestNewTrierWithErrorCapacity(t *testing.T) {
+	// Act
+	tr := NewTrier(WithErrorCapacity(100))
+
+	// Assert
+	assert.Equal(t, 100, cap(tr.errs))
+	assert.Nil(t, tr.Err())
+}
+
+func TestTrierWithErrorRetention(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithErrorRetention(2))
+
+	// Act
+	for i := 0; i < 5; i++ {
+		tr.TryJoin(func(args ...any) error {
+			return fmt.Errorf("error %d", i)
+		})
+	}
+
+	// Assert
+	assert.Equal(t, 3, tr.Dropped())
+	assert.EqualError(t, tr.Err(), "3 more errors dropped\nerror 1\nerror 0")
+
+	var dropped *DroppedError
+	assert.True(t, errors.As(tr.Err(), &dropped))
+	assert.Equal(t, 3, dropped.Count)
+}
+
+func TestTrierWithErrorRetentionSingle(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithErrorRetention(1))
+
+	// Act
+	tr.TryJoin(passOrFail, true).TryJoin(passOrFail, true)
+	tr.Nil()
+	tr.Try(passOrFail, true)
+
+	// Assert
+	assert.Equal(t, 0, tr.Dropped())
+	assert.EqualError(t, tr.Err(), "failed passOrFail")
+}
+
+func TestTrierWithFirstErrorOnly(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithFirstErrorOnly())
+
+	joined := false
+
+	// Act
+	tr.Try(passOrFail, true).
+		TryJoin(func(args ...any) error {
+			joined = true
+			return errors.New("cleanup failed")
+		})
+
+	// Assert
+	assert.True(t, joined)
+	assert.EqualError(t, tr.Err(), "failed passOrFail")
+}
+
+func TestTrierWithFirstErrorOnlyRetry(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithFirstErrorOnly())
+
+	attempt := 0
+
+	// Act
+	tr.TryRetry(3, func(args ...any) error {
+		attempt++
+		return fmt.Errorf("attempt %d", attempt)
+	})
+
+	// Assert
+	assert.Equal(t, 3, attempt)
+	assert.EqualError(t, tr.Err(), "attempt 3")
+}
+
+func TestWithStepResults(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithStepResults())
+
+	attempt := 0
+
+	// Act
+	tr.Try(passOrFail).
+		TryWith(func(args ...any) error {
+			attempt++
+			if attempt == 1 {
+				return fail()
+			}
+			return nil
+		}, WithStepName("flaky"), WithRetry(3)).
+		TryBatchParallel(2, []func() error{pass, pass})
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, []StepResult{
+		{Index: 0, Attempts: 1},
+		{Index: 1, Name: "flaky", Attempts: 2},
+		{Index: 2, Attempts: 1},
+		{Index: 3, Attempts: 1},
+	}, tr.Steps())
+}
+
+func TestTrierStepsNotKept(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.Try(passOrFail)
+
+	// Assert
+	assert.Empty(t, tr.Steps())
+}
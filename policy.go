@@ -0,0 +1,65 @@
+package trier
+
+import (
+	"math"
+	"time"
+)
+
+// RetryPolicy bundles up retry behavior so it
+// can be defined once and shared between any
+// number of calls with WithPolicy. It's a plain
+// value that WithPolicy copies, so goroutines
+// can share one without any locking
+type RetryPolicy struct {
+	// Attempts is passed to WithRetry if
+	// it isn't zero. Less than zero retries
+	// until the function succeeds
+	Attempts int
+	// Backoff is passed to WithBackoff if set
+	Backoff func(i int) time.Duration
+	// Jitter is passed to WithJitter if set
+	Jitter float64
+	// RetryIf is passed to WithRetryIf if set
+	RetryIf func(err error) bool
+	// OnRetry is passed to WithOnRetry if set
+	OnRetry func(attempt int, err error)
+}
+
+// WithPolicy applies every part of p that is
+// set. Options after it override those parts
+func WithPolicy(p RetryPolicy) TryOption {
+	return func(c *tryConfig) {
+		if p.Attempts != 0 {
+			c.limit = p.Attempts
+		}
+
+		if p.Backoff != nil {
+			c.backoff = p.Backoff
+		}
+
+		if p.Jitter > 0 {
+			c.jitter = p.Jitter
+		}
+
+		if p.RetryIf != nil {
+			c.retryIf = p.RetryIf
+		}
+
+		if p.OnRetry != nil {
+			c.onRetry = p.OnRetry
+		}
+	}
+}
+
+// ExponentialBackoff returns a backoff func
+// that doubles base for every failed attempt,
+// never waiting longer than ceiling
+func ExponentialBackoff(base, ceiling time.Duration) func(i int) time.Duration {
+	return func(i int) time.Duration {
+		d := float64(base) * math.Pow(2, float64(i))
+		if d > float64(ceiling) {
+			return ceiling
+		}
+		return time.Duration(d)
+	}
+}
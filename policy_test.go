@@ -0,0 +1,68 @@
+package trier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrierTryWithPolicy(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	policy := RetryPolicy{
+		Attempts: 3,
+		Backoff: func(i int) time.Duration {
+			return time.Millisecond
+		},
+	}
+
+	calls := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		calls++
+		return fail()
+	}, WithPolicy(policy))
+
+	// Assert
+	assert.NotNil(t, tr.Err())
+	assert.Equal(t, 3, calls)
+}
+
+func TestTrierTryWithPolicyOverridden(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		calls++
+		return fail()
+	}, WithPolicy(RetryPolicy{Attempts: 3}), WithRetry(1))
+
+	// Assert
+	assert.Equal(t, 1, calls)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	// Arrange
+	backoff := ExponentialBackoff(time.Millisecond, 5*time.Millisecond)
+
+	// Act
+	var got []time.Duration
+	for i := 0; i < 5; i++ {
+		got = append(got, backoff(i))
+	}
+
+	// Assert
+	assert.Equal(t, []time.Duration{
+		time.Millisecond,
+		2 * time.Millisecond,
+		4 * time.Millisecond,
+		5 * time.Millisecond,
+		5 * time.Millisecond,
+	}, got)
+}
@@ -0,0 +1,33 @@
+package trier
+
+import "sync"
+
+var pool = sync.Pool{
+	New: func() any {
+		return new(Trier)
+	},
+}
+
+// Get is like NewTrier, but reuses a *Trier
+// previously passed to Release if one is
+// available, so servers creating a Trier per
+// request don't allocate one every time
+func Get(opts ...Option) *Trier {
+	t := pool.Get().(*Trier)
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// Release resets every part of t, including
+// its error and options, and returns it to
+// the pool used by Get. t must not be used
+// after it's released, so read anything
+// needed from it (like Err) beforehand
+func (t *Trier) Release() {
+	*t = Trier{}
+	pool.Put(t)
+}
@@ -0,0 +1,46 @@
+package trier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	// Arrange
+	tr := Get(WithName("request"))
+
+	// Act
+	tr.TryFunc(fail)
+
+	// Assert
+	assert.EqualError(t, tr.Err(), "request: failed fail")
+}
+
+func TestTrierRelease(t *testing.T) {
+	// Arrange
+	tr := Get(WithName("request"), WithChainTimeout(time.Minute), WithErrorBudget(1, time.Minute))
+	tr.TryFunc(fail).TryFunc(pass)
+
+	// Act
+	tr.Release()
+
+	// Assert
+	assert.Equal(t, Trier{}, *tr)
+}
+
+func TestGetAfterRelease(t *testing.T) {
+	// Arrange
+	first := Get(WithName("first"))
+	first.TryFunc(fail)
+	first.Release()
+
+	// Act
+	tr := Get()
+	tr.TryFunc(pass)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Empty(t, tr.Name())
+}
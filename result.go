@@ -0,0 +1,130 @@
+package trier
+
+import "sync"
+
+// Result holds either a value or the error
+// that kept it from being produced, for
+// value-oriented code that would rather pass
+// one thing around than a (T, error) pair
+type Result[T any] struct {
+	v   T
+	err error
+}
+
+// Ok returns a Result holding v
+func Ok[T any](v T) Result[T] {
+	return Result[T]{v: v}
+}
+
+// Err returns a Result holding err
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// ResultOf returns a Result holding err if
+// it isn't nil, or v otherwise, so functions
+// returning (T, error) can be adapted with
+// ResultOf(fn())
+func ResultOf[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(v)
+}
+
+// Unwrap returns the Result's value and
+// error as a (T, error) pair. The value is
+// T's zero value if the Result holds an error
+func (r Result[T]) Unwrap() (T, error) {
+	return r.v, r.err
+}
+
+// IsOk reports whether the Result
+// holds a value rather than an error
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Err returns the error the Result
+// holds, or nil if it holds a value
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Map returns a Result holding fn applied
+// to r's value, or r's error if it holds one
+func Map[T, U any](r Result[T], fn func(v T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(fn(r.v))
+}
+
+// AndThen returns the Result of calling fn
+// with r's value, or r's error if it holds
+// one, chaining steps that can each fail
+func AndThen[T, U any](r Result[T], fn func(v T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return fn(r.v)
+}
+
+// TryResult tries fn on t like TryFunc, with
+// opts applying just as they do to TryWith,
+// recording the error of the Result fn returns
+// if it holds one. The Result returned holds
+// fn's value if the step succeeded, or the
+// error t recorded for it if it didn't. If an
+// errFn dropped the error, so nothing was
+// recorded, it holds the error of the Result
+// fn last returned. If t already held an
+// error, so fn wasn't tried, it holds the
+// error returned by t's Err
+func TryResult[T any](t *Trier, fn func() Result[T], opts ...TryOption) Result[T] {
+	// attempts that time out are left running,
+	// so one finishing late mustn't overwrite
+	// the result of an attempt started after it
+	var (
+		mu       sync.Mutex
+		r        Result[T]
+		started  int
+		finished int
+	)
+
+	c := newTryConfig(opts)
+	if fn != nil {
+		c.plain = func() error {
+			mu.Lock()
+			started++
+			n := started
+			mu.Unlock()
+
+			res := fn()
+
+			mu.Lock()
+			if n > finished {
+				r, finished = res, n
+			}
+			mu.Unlock()
+
+			return res.err
+		}
+	}
+
+	_, err := t.try(nil, &c)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	switch {
+	case err != nil:
+		return Err[T](err)
+	case finished == 0:
+		return Err[T](t.Err())
+	case r.err != nil:
+		return Err[T](r.err)
+	}
+
+	return Ok(r.v)
+}
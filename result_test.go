@@ -0,0 +1,166 @@
+package trier
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parse(s string) Result[int] {
+	return ResultOf(strconv.Atoi(s))
+}
+
+func TestResultOk(t *testing.T) {
+	// Arrange
+	r := Ok(2)
+
+	// Act
+	v, err := r.Unwrap()
+
+	// Assert
+	assert.True(t, r.IsOk())
+	assert.Equal(t, 2, v)
+	assert.Nil(t, err)
+}
+
+func TestResultErr(t *testing.T) {
+	// Arrange
+	r := Err[int](fail())
+
+	// Act
+	v, err := r.Unwrap()
+
+	// Assert
+	assert.False(t, r.IsOk())
+	assert.Equal(t, 0, v)
+	assert.Equal(t, "failed fail", err.Error())
+	assert.Equal(t, err, r.Err())
+}
+
+func TestResultMap(t *testing.T) {
+	// Arrange
+	double := func(v int) int {
+		return v * 2
+	}
+
+	// Act
+	ok := Map(parse("21"), double)
+	bad := Map(parse("x"), double)
+
+	// Assert
+	assert.Equal(t, Ok(42), ok)
+	assert.False(t, bad.IsOk())
+}
+
+func TestResultAndThen(t *testing.T) {
+	// Arrange
+	positive := func(v int) Result[uint] {
+		if v < 0 {
+			return Err[uint](errors.New("negative"))
+		}
+		return Ok(uint(v))
+	}
+
+	// Act
+	ok := AndThen(parse("3"), positive)
+	negative := AndThen(parse("-3"), positive)
+	bad := AndThen(parse("x"), positive)
+
+	// Assert
+	assert.Equal(t, Ok[uint](3), ok)
+	assert.Equal(t, "negative", negative.Err().Error())
+	assert.ErrorIs(t, bad.Err(), strconv.ErrSyntax)
+}
+
+func TestTryResult(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	r := TryResult(tr, func() Result[int] {
+		return parse("42")
+	})
+
+	// Assert
+	assert.Equal(t, Ok(42), r)
+	assert.Nil(t, tr.Err())
+}
+
+func TestTryResultErr(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	attempts := 0
+
+	// Act
+	r := TryResult(tr, func() Result[int] {
+		attempts++
+		return parse("x")
+	}, WithRetry(2), WithStepName("parse"))
+
+	skipped := TryResult(tr, func() Result[int] {
+		attempts++
+		return Ok(1)
+	})
+
+	// Assert
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, tr.Err(), r.Err())
+	assert.ErrorIs(t, r.Err(), strconv.ErrSyntax)
+	assert.Equal(t, tr.Err(), skipped.Err())
+}
+
+func TestTryResultErrDropped(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	bad := errors.New("bad")
+
+	// Act
+	r := TryResult(tr, func() Result[int] {
+		return Err[int](bad)
+	}, WithOnErr(func(err error) error {
+		return nil
+	}))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.False(t, r.IsOk())
+	assert.Equal(t, bad, r.Err())
+}
+
+func TestTryResultRetried(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	var attempts atomic.Int32
+
+	// Act
+	r := TryResult(tr, func() Result[string] {
+		if attempts.Add(1) == 1 {
+			time.Sleep(20 * time.Millisecond)
+			return Ok("late")
+		}
+		return Ok("on time")
+	}, WithRetry(2), WithTimeout(5*time.Millisecond))
+
+	time.Sleep(30 * time.Millisecond)
+
+	// Assert
+	assert.Equal(t, Ok("on time"), r)
+}
+
+func TestTryResultNil(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	r := TryResult[int](tr, nil)
+
+	// Assert
+	assert.ErrorIs(t, r.Err(), ErrNilFunc)
+}
@@ -0,0 +1,32 @@
+package trier
+
+import "context"
+
+// Semaphore is a weighted semaphore, as
+// implemented by *semaphore.Weighted from
+// golang.org/x/sync/semaphore
+type Semaphore interface {
+	Acquire(ctx context.Context, n int64) error
+	Release(n int64)
+}
+
+// limiter is a Semaphore of cap(l) units
+type limiter chan struct{}
+
+func (l limiter) Acquire(ctx context.Context, n int64) error {
+	for i := int64(0); i < n; i++ {
+		select {
+		case l <- struct{}{}:
+		case <-ctx.Done():
+			l.Release(i)
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (l limiter) Release(n int64) {
+	for ; n > 0; n-- {
+		<-l
+	}
+}
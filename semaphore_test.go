@@ -0,0 +1,158 @@
+package trier
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// peak tracks the most weight
+// in use at any one time
+type peak struct {
+	inUse, most atomic.Int64
+}
+
+func (p *peak) step(weight int64) Step {
+	return Step{
+		Weight: weight,
+		Fn: func(args ...any) error {
+			n := p.inUse.Add(weight)
+			defer p.inUse.Add(-weight)
+
+			for {
+				m := p.most.Load()
+				if n <= m || p.most.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		},
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	// Arrange
+	l := make(limiter, 2)
+
+	// Act
+	err := l.Acquire(context.Background(), 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	blocked := l.Acquire(ctx, 1)
+
+	l.Release(2)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.ErrorIs(t, blocked, context.DeadlineExceeded)
+	assert.Len(t, l, 0)
+}
+
+func TestChainExecuteWeighted(t *testing.T) {
+	// Arrange
+	var p peak
+
+	sem := make(limiter, 4)
+
+	c := NewChain().Then(p.step(3), p.step(1), p.step(3), p.step(1))
+
+	// Act
+	report, err := c.ExecuteWeighted(context.Background(), sem)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, p.most.Load(), int64(4))
+	for _, result := range report.Steps {
+		assert.False(t, result.Skipped)
+	}
+}
+
+func TestChainExecuteWeightedShared(t *testing.T) {
+	// Arrange
+	var p peak
+
+	sem := make(limiter, 2)
+
+	first := NewChain().Then(p.step(2), p.step(1))
+	second := NewChain().Then(p.step(1), p.step(2))
+
+	var wg sync.WaitGroup
+
+	// Act
+	for _, c := range []*Chain{first, second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.ExecuteWeighted(context.Background(), sem)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.LessOrEqual(t, p.most.Load(), int64(2))
+}
+
+func TestChainExecuteWeightedTooHeavy(t *testing.T) {
+	// Arrange
+	var p peak
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	c := NewChain().Then(p.step(5))
+
+	// Act
+	report, err := c.ExecuteWeighted(ctx, make(limiter, 1))
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, report.Steps[0].Skipped)
+}
+
+func TestTrierTryWithSemaphore(t *testing.T) {
+	// Arrange
+	var p peak
+
+	sem := make(limiter, 2)
+
+	var wg sync.WaitGroup
+
+	// Act
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tr := NewTrier().TryWith(p.step(1).Fn, WithSemaphore(sem, 1))
+			assert.Nil(t, tr.Err())
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int64(2), p.most.Load())
+}
+
+func TestTrierTryWithSemaphoreCanceled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sem := make(limiter, 1)
+	_ = sem.Acquire(context.Background(), 1)
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryWith(passOrFail, WithSemaphore(sem, 1), WithContext(ctx))
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), context.Canceled)
+}
@@ -0,0 +1,91 @@
+package trier
+
+import "iter"
+
+// TrySeq ranges over seq and tries fn with every
+// value it yields, stopping as soon as fn returns
+// an error. Like Try, nothing is done if the
+// Trier already holds an error. opts apply to
+// every value, so each one can be retried, and
+// WithJoin collects errors from every value
+// instead of stopping at the first one, though
+// ranging stops once the Trier's chain timeout
+// has passed or its error budget is shed
+func TrySeq[T any](t *Trier, seq iter.Seq[T], fn func(v T) error, opts ...TryOption) *Trier {
+	c := newTryConfig(opts)
+
+	if fn == nil {
+		t.try(nil, &c)
+		return t
+	}
+
+	// checked before ranging too, so seq
+	// isn't pulled from for nothing
+	if t.stopped(&c) {
+		return t
+	}
+
+	for v := range seq {
+		if t.stopped(&c) {
+			break
+		}
+
+		t.try(func(args ...any) error {
+			return fn(v)
+		}, &c)
+	}
+
+	return t
+}
+
+// TrySeq2 is like TrySeq, but for sequences that
+// yield a value alongside an error (such as rows
+// read from a database). A non-nil error yielded
+// by seq is recorded as the step for its paired
+// value, which fn isn't tried with
+func TrySeq2[T any](t *Trier, seq iter.Seq2[T, error], fn func(v T) error, opts ...TryOption) *Trier {
+	c := newTryConfig(opts)
+
+	if fn == nil {
+		t.try(nil, &c)
+		return t
+	}
+
+	// checked before ranging too, so seq
+	// isn't pulled from for nothing
+	if t.stopped(&c) {
+		return t
+	}
+
+	for v, err := range seq {
+		if t.stopped(&c) {
+			break
+		}
+
+		if err != nil {
+			// tried just once, as retrying
+			// can't change what seq yielded
+			once := c
+			once.limit = 1
+
+			t.try(func(args ...any) error {
+				return err
+			}, &once)
+
+			continue
+		}
+
+		t.try(func(args ...any) error {
+			return fn(v)
+		}, &c)
+	}
+
+	return t
+}
+
+// stopped reports whether ranging over a
+// sequence should stop, since no more of
+// its values would be tried
+func (t *Trier) stopped(c *tryConfig) bool {
+	return t.timedOut || t.shed || (t.failed() && !c.join)
+}
@@ -0,0 +1,223 @@
+package trier
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func failIfNegative(v int) error {
+	if v < 0 {
+		return errors.New("failedIfNegative")
+	}
+	return nil
+}
+
+func pairs(vals []int, errAt int) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for i, v := range vals {
+			var err error
+			if i == errAt {
+				err = errors.New("failed pairs")
+			}
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+func TestTrySeq(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	seen := 0
+
+	// Act
+	TrySeq(tr, slices.Values([]int{1, 2, 3}), func(v int) error {
+		seen++
+		return failIfNegative(v)
+	})
+
+	// Assert
+	assert.Empty(t, tr.errs)
+	assert.Equal(t, 3, seen)
+}
+
+func TestTrySeqError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	seen := 0
+
+	// Act
+	TrySeq(tr, slices.Values([]int{1, -2, 3}), func(v int) error {
+		seen++
+		return failIfNegative(v)
+	})
+
+	// Assert
+	assert.Equal(t, "failedIfNegative", tr.Err().Error())
+	assert.Equal(t, 2, seen)
+}
+
+func TestTrySeqPreviousError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	seen := 0
+
+	// Act
+	tr.Try(passOrFail, true)
+	TrySeq(tr, slices.Values([]int{1, 2, 3}), func(v int) error {
+		seen++
+		return nil
+	})
+
+	// Assert
+	assert.Equal(t, "failed passOrFail", tr.Err().Error())
+	assert.Equal(t, 0, seen)
+}
+
+func TestTrySeq2PreviousErrorNotPulled(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	pulled := 0
+
+	seq := func(yield func(int, error) bool) {
+		for i := range 3 {
+			pulled++
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+
+	// Act
+	tr.Try(passOrFail, true)
+	TrySeq2(tr, seq, func(v int) error {
+		return nil
+	})
+
+	// Assert
+	assert.Equal(t, "failed passOrFail", tr.Err().Error())
+	assert.Equal(t, 0, pulled)
+}
+
+func TestTrySeq2(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	seen := 0
+
+	// Act
+	TrySeq2(tr, pairs([]int{1, 2, 3}, -1), func(v int) error {
+		seen++
+		return failIfNegative(v)
+	})
+
+	// Assert
+	assert.Empty(t, tr.errs)
+	assert.Equal(t, 3, seen)
+}
+
+func TestTrySeq2YieldedError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	seen := 0
+
+	// Act
+	TrySeq2(tr, pairs([]int{1, 2, 3}, 1), func(v int) error {
+		seen++
+		return failIfNegative(v)
+	})
+
+	// Assert
+	assert.Equal(t, "failed pairs", tr.Err().Error())
+	assert.Equal(t, 1, seen)
+}
+
+func TestTrySeqJoin(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	TrySeq(tr, slices.Values([]int{-1, 2, -3}), failIfNegative, WithJoin())
+
+	// Assert
+	assert.Equal(t, "failedIfNegative\nfailedIfNegative", tr.Err().Error())
+}
+
+func TestTrySeqRetry(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	// Act
+	TrySeq(tr, slices.Values([]int{1, 2}), func(v int) error {
+		calls++
+		if calls%2 == 1 {
+			return errors.New("flaky")
+		}
+		return nil
+	}, WithRetry(2))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, 4, calls)
+}
+
+func naturals() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestTrySeqJoinChainTimeout(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithChainTimeout(10 * time.Millisecond))
+
+	// Act
+	TrySeq(tr, naturals(), func(v int) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	}, WithJoin())
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrChainTimeout)
+}
+
+func TestTrySeqJoinShed(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithErrorBudget(2, time.Minute))
+
+	// Act
+	TrySeq(tr, naturals(), func(v int) error {
+		return errors.New("failed naturals")
+	}, WithJoin())
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrBudgetExhausted)
+}
+
+func TestTrySeq2YieldedErrorNamed(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	TrySeq2(tr, pairs([]int{1, 2, 3}, 1), failIfNegative, WithStepName("row"), WithRetry(3), WithJoin())
+
+	// Assert
+	assert.Equal(t, &StepError{Index: 1, Name: "row", Err: errors.New("failed pairs")}, tr.Err())
+}
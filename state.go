@@ -0,0 +1,115 @@
+package trier
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// state is the JSON form of a Trier's
+// execution state
+type state struct {
+	Name     string       `json:"name,omitempty"`
+	Steps    int          `json:"steps"`
+	Errors   []errorState `json:"errors,omitempty"`
+	Dropped  int          `json:"dropped,omitempty"`
+	TimedOut bool         `json:"timedOut,omitempty"`
+}
+
+// errorState is the JSON form of a
+// single error held by a Trier
+type errorState struct {
+	Message string `json:"message"`
+	// Step is a pointer so step
+	// zero isn't left out
+	Step     *int   `json:"step,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Attempts int    `json:"attempts,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+// MarshalJSON encodes the Trier's execution
+// state: how many steps it has tried and the
+// errors it holds, along with the step and
+// attempts recorded for each of them, so a
+// partially failed run can be persisted and
+// picked up elsewhere with Chain's Resume.
+// Options (besides the name) and any
+// functions it holds aren't encoded
+func (t *Trier) MarshalJSON() ([]byte, error) {
+	s := state{
+		Name:     t.name,
+		Steps:    t.steps,
+		Dropped:  t.dropped,
+		TimedOut: t.timedOut,
+	}
+
+	for _, err := range t.errs {
+		s.Errors = append(s.Errors, encodeErr(err))
+	}
+
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON restores the execution state
+// encoded by MarshalJSON. Errors are restored
+// with their messages, steps and attempts,
+// but not their types, so errors.Is won't
+// match the sentinels they wrapped
+func (t *Trier) UnmarshalJSON(data []byte) error {
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	t.name = s.Name
+	t.steps = s.Steps
+	t.dropped = s.Dropped
+	t.timedOut = s.TimedOut
+
+	// a fresh slice, since snapshots taken
+	// with Immutable may share the old one
+	t.errs = make([]error, 0, len(s.Errors))
+	for _, es := range s.Errors {
+		t.errs = append(t.errs, decodeErr(es))
+	}
+
+	return nil
+}
+
+// encodeErr unwraps the step and
+// attempts recorded for err
+func encodeErr(err error) errorState {
+	var es errorState
+
+	if se, ok := err.(*StepError); ok {
+		es.Step = &se.Index
+		es.Name = se.Name
+		err = se.Err
+	}
+
+	if re, ok := err.(*RetryError); ok {
+		es.Attempts = re.Attempts
+		es.Limit = re.Limit
+		err = re.Err
+	}
+
+	es.Message = err.Error()
+
+	return es
+}
+
+// decodeErr rebuilds the error
+// encoded by encodeErr
+func decodeErr(es errorState) error {
+	err := errors.New(es.Message)
+
+	if es.Attempts > 0 {
+		err = &RetryError{Err: err, Attempts: es.Attempts, Limit: es.Limit}
+	}
+
+	if es.Step != nil {
+		err = &StepError{Index: *es.Step, Name: es.Name, Err: err}
+	}
+
+	return err
+}
@@ -0,0 +1,204 @@
+package trier
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrierMarshalJSON(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithName("loader"))
+
+	tr.Try(passOrFail).
+		TryWith(passOrFail, WithArgs(true), WithStepName("save"), WithRetry(2))
+
+	// Act
+	data, err := json.Marshal(tr)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "loader",
+		"steps": 2,
+		"errors": [{"message": "failed passOrFail (2 times)", "step": 1, "name": "save", "attempts": 2, "limit": 2}]
+	}`, string(data))
+}
+
+func TestTrierUnmarshalJSON(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithName("loader"), WithErrorRetention(1))
+
+	tr.Try(passOrFail).
+		TryWith(passOrFail, WithArgs(true), WithStepName("save"), WithRetry(2)).
+		TryJoin(failIfString, "hi")
+
+	data, _ := json.Marshal(tr)
+
+	restored := NewTrier()
+
+	// Act
+	err := json.Unmarshal(data, restored)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, tr.Err().Error(), restored.Err().Error())
+	assert.Equal(t, 1, restored.Dropped())
+
+	info, ok := RetryInfo(restored.Err())
+	assert.True(t, ok)
+	assert.Equal(t, 2, info.Attempts)
+
+	var se *StepError
+	assert.True(t, errors.As(restored.Err(), &se))
+	assert.Equal(t, 1, se.Index)
+}
+
+func TestTrierUnmarshalJSONImmutable(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithErrorCapacity(2))
+	tr.Try(passOrFail, true)
+
+	snapshot := tr.Immutable()
+
+	// Act
+	err := tr.UnmarshalJSON([]byte(`{"steps": 1, "errors": [{"message": "overwritten"}]}`))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "overwritten", tr.Err().Error())
+	assert.Equal(t, "failed passOrFail", snapshot.Err().Error())
+}
+
+func TestTrierUnmarshalJSONInvalid(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	err := json.Unmarshal([]byte(`{"steps": "two"}`), tr)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, tr.Err())
+}
+
+func TestChainResume(t *testing.T) {
+	// Arrange
+	calls := make([]int, 3)
+	healthy := false
+
+	step := func(i int) Step {
+		return Step{Fn: func(args ...any) error {
+			calls[i]++
+			if i == 1 && !healthy {
+				return errors.New("unavailable")
+			}
+			return nil
+		}}
+	}
+
+	chain := NewChain().Then(step(0), step(1), step(2))
+
+	tr := NewTrier()
+	_, err := chain.Resume(tr)
+	assert.Error(t, err)
+
+	data, _ := json.Marshal(tr)
+
+	restored := NewTrier()
+	_ = json.Unmarshal(data, restored)
+
+	healthy = true
+
+	// Act
+	report, err := chain.Resume(restored)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 1}, calls)
+	assert.True(t, report.Steps[0].Skipped)
+	assert.Equal(t, 1, report.Steps[1].Attempts)
+	assert.Equal(t, 1, report.Steps[2].Attempts)
+}
+
+func TestChainResumeErrorCapacity(t *testing.T) {
+	// Arrange
+	var ran []string
+
+	step := func(name string, fail bool) Step {
+		return Step{Name: name, Fn: func(args ...any) error {
+			ran = append(ran, name)
+			if fail {
+				return errors.New(name + " failed")
+			}
+			return nil
+		}}
+	}
+
+	tr := NewTrier()
+	_, _ = NewChain().Then(step("a", false), step("b", true)).Resume(tr)
+
+	ran = nil
+
+	chain := NewChain(WithErrorCapacity(4)).Then(step("a", false), step("b", false), step("c", false))
+
+	// Act
+	report, err := chain.Resume(tr)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, ran)
+	assert.False(t, report.Steps[1].Skipped)
+}
+
+func TestChainResumeShed(t *testing.T) {
+	// Arrange
+	budget := NewErrorBudget(1, 20*time.Millisecond)
+
+	var ran []string
+
+	chain := NewChain(WithSharedErrorBudget(budget)).Then(
+		Step{Name: "charge", Fn: func(args ...any) error {
+			ran = append(ran, "charge")
+			budget.Fail()
+			return nil
+		}},
+		Step{Name: "ship", Fn: func(args ...any) error {
+			ran = append(ran, "ship")
+			return nil
+		}},
+	)
+
+	tr := NewTrier()
+	_, err := chain.Resume(tr)
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+
+	time.Sleep(30 * time.Millisecond)
+
+	// Act
+	_, err = chain.Resume(tr)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"charge", "ship"}, ran)
+}
+
+func TestChainResumeFresh(t *testing.T) {
+	// Arrange
+	chain := NewChain(WithName("fresh")).Then(
+		Step{Fn: passOrFail},
+		Step{Fn: passOrFail, Args: []any{true}},
+	)
+
+	// Act
+	report, err := chain.Resume(NewTrier())
+
+	// Assert
+	assert.Equal(t, "fresh: failed passOrFail", err.Error())
+	assert.Equal(t, "fresh", report.Name)
+	assert.Equal(t, 1, report.Steps[0].Attempts)
+	assert.Equal(t, 1, report.Steps[1].Attempts)
+}
@@ -0,0 +1,115 @@
+package trier
+
+import (
+	"fmt"
+	"time"
+)
+
+// Step bundles a function together with the
+// args it should be called with and the policy
+// it should be tried with, so a recurring
+// operation can be defined once (even in a
+// package-level var) and tried with TryStep
+// wherever it's needed
+type Step struct {
+	// Name identifies the step. If set, any
+	// error returned by Fn is wrapped in a
+	// *StepError carrying it
+	Name string
+	// Fn is the function to try
+	Fn func(args ...any) error
+	// Args are passed to Fn on every attempt
+	Args []any
+	// Retry is the limit passed to TryRetry.
+	// Zero means Fn is only tried once, and
+	// less than zero retries until Fn succeeds
+	Retry int
+	// Backoff, if set, is waited on between
+	// retries just like with TryRetryBackoff
+	Backoff func(i int) time.Duration
+	// OnErr, if set, is passed any error
+	// just like the errFn of TryIfErr
+	OnErr func(err error) error
+	// Timeout, if set, fails any attempt
+	// that takes longer, like WithTimeout
+	Timeout time.Duration
+	// Recover turns any panic raised by Fn
+	// into an error, like WithRecover
+	Recover bool
+	// DependsOn names the steps that must
+	// succeed before this one is started by
+	// (*Chain).ExecuteParallel. Execute always
+	// runs steps in the order they were added
+	DependsOn []string
+	// Weight is how much of the Semaphore given
+	// to (*Chain).ExecuteWeighted the step
+	// needs while running. Zero means one
+	Weight int64
+}
+
+// StepError is returned for errors
+// produced by a named Step
+type StepError struct {
+	// Index is the zero-based position of
+	// the step among those the Trier tried
+	Index int
+	// Name is the step's name, which is
+	// empty for unnamed steps, such as those
+	// of a Trier created with ForTest or
+	// ones failing validation
+	Name string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("step %d: %s", e.Index, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Name, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// TryStep tries step with the policy it describes
+func (t *Trier) TryStep(step Step) *Trier {
+	return t.TryWith(step.Fn, step.options()...)
+}
+
+// weight returns the step's Weight,
+// defaulting to one
+func (s Step) weight() int64 {
+	if s.Weight <= 0 {
+		return 1
+	}
+	return s.Weight
+}
+
+// options translates the step's
+// policy into TryOptions
+func (s Step) options() []TryOption {
+	opts := []TryOption{WithArgs(s.Args...), WithStepName(s.Name)}
+
+	if s.Retry != 0 {
+		opts = append(opts, WithRetry(s.Retry))
+	}
+
+	if s.Backoff != nil {
+		opts = append(opts, WithBackoff(s.Backoff))
+	}
+
+	if s.OnErr != nil {
+		opts = append(opts, WithOnErr(s.OnErr))
+	}
+
+	if s.Timeout > 0 {
+		opts = append(opts, WithTimeout(s.Timeout))
+	}
+
+	if s.Recover {
+		opts = append(opts, WithRecover())
+	}
+
+	return opts
+}
@@ -0,0 +1,95 @@
+package trier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var failingStep = Step{
+	Name: "failing",
+	Fn:   passOrFail,
+	Args: []any{true},
+}
+
+func TestTrierTryStep(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryStep(Step{Fn: passOrFail})
+
+	// Assert
+	assert.Empty(t, tr.errs)
+}
+
+func TestTrierTryStepError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryStep(failingStep)
+
+	// Assert
+	var stepErr *StepError
+	assert.ErrorAs(t, tr.Err(), &stepErr)
+	assert.Equal(t, "failing", stepErr.Name)
+	assert.Equal(t, "failing: failed passOrFail", tr.Err().Error())
+}
+
+func TestTrierTryStepReused(t *testing.T) {
+	// Arrange
+	first := NewTrier()
+	second := NewTrier()
+
+	// Act
+	first.TryStep(failingStep)
+	second.Try(passOrFail).TryStep(failingStep)
+
+	// Assert
+	assert.Equal(t, first.Err().Error(), second.Err().Error())
+}
+
+func TestTrierTryStepOnErr(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	errDomain := errors.New("domain error")
+
+	// Act
+	tr.TryStep(Step{
+		Fn:   passOrFail,
+		Args: []any{true},
+		OnErr: func(err error) error {
+			return errors.Join(errDomain, err)
+		},
+	})
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), errDomain)
+}
+
+func TestTrierTryStepRetry(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	// Act
+	tr.TryStep(Step{
+		Name: "flaky",
+		Fn: func(args ...any) error {
+			calls++
+			if calls < 3 {
+				return fail()
+			}
+			return nil
+		},
+		Retry: -1,
+	})
+
+	// Assert
+	assert.Empty(t, tr.errs)
+	assert.Equal(t, 3, calls)
+}
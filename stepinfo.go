@@ -0,0 +1,85 @@
+package trier
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync/atomic"
+)
+
+// StepInfo describes the attempt
+// a step run with TryCtx is making
+type StepInfo struct {
+	// Index is the step's position in the chain
+	Index int
+	// Name is the name given with WithStepName
+	Name string
+	// Attempt is the attempt being made,
+	// starting at one
+	Attempt int
+	// IdempotencyKey is generated once for the
+	// step, so it's the same for every attempt
+	// but differs between steps and between
+	// calls to TryCtx
+	IdempotencyKey string
+}
+
+type stepInfoKey struct{}
+
+// InfoFromContext returns the StepInfo carried
+// by the ctx passed to a step run with TryCtx
+func InfoFromContext(ctx context.Context) (StepInfo, bool) {
+	info, ok := ctx.Value(stepInfoKey{}).(StepInfo)
+	return info, ok
+}
+
+// IdempotencyKey returns the idempotency key
+// of the step ctx was passed to by TryCtx, or
+// an empty string if it wasn't. Sending it
+// along with side-effecting requests (such as
+// in an Idempotency-Key header) lets servers
+// tell a retry apart from a new operation
+func IdempotencyKey(ctx context.Context) string {
+	info, _ := InfoFromContext(ctx)
+	return info.IdempotencyKey
+}
+
+// NewIdempotencyKey returns a new random key
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// TryCtx is like TryWith, but fn is passed a
+// context derived from ctx (which is also used
+// as if given to WithContext) carrying a StepInfo
+// for the attempt, retrievable with
+// InfoFromContext and IdempotencyKey
+func (t *Trier) TryCtx(ctx context.Context, fn func(ctx context.Context) error, opts ...TryOption) *Trier {
+	c := newTryConfig(append([]TryOption{WithContext(ctx)}, opts...))
+
+	info := StepInfo{
+		Index:          t.steps,
+		Name:           c.name,
+		IdempotencyKey: t.rnd.key(),
+	}
+
+	// a nil fn is left nil for
+	// try to record ErrNilFunc
+	var try func(args ...any) error
+	if fn != nil {
+		var attempt atomic.Int32
+
+		try = func(args ...any) error {
+			info := info
+			info.Attempt = int(attempt.Add(1))
+
+			return fn(context.WithValue(ctx, stepInfoKey{}, info))
+		}
+	}
+
+	t.try(try, &c)
+
+	return t
+}
@@ -0,0 +1,88 @@
+package trier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrierTryCtx(t *testing.T) {
+	// Arrange
+	var infos []StepInfo
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(pass).
+		TryCtx(context.Background(), func(ctx context.Context) error {
+			info, ok := InfoFromContext(ctx)
+			assert.True(t, ok)
+
+			infos = append(infos, info)
+			if len(infos) < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		}, WithStepName("charge"), WithRetry(3))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Len(t, infos, 3)
+	for i, info := range infos {
+		assert.Equal(t, 1, info.Index)
+		assert.Equal(t, "charge", info.Name)
+		assert.Equal(t, i+1, info.Attempt)
+		assert.Equal(t, infos[0].IdempotencyKey, info.IdempotencyKey)
+	}
+	assert.Len(t, infos[0].IdempotencyKey, 32)
+}
+
+func TestTrierTryCtxKeysDiffer(t *testing.T) {
+	// Arrange
+	var keys []string
+
+	step := func(ctx context.Context) error {
+		keys = append(keys, IdempotencyKey(ctx))
+		return nil
+	}
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryCtx(context.Background(), step).
+		TryCtx(context.Background(), step)
+
+	// Assert
+	assert.Len(t, keys, 2)
+	assert.NotEqual(t, keys[0], keys[1])
+}
+
+func TestTrierTryCtxCanceled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+
+	tr := NewTrier()
+
+	// Act
+	tr.TryCtx(ctx, func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	// Assert
+	assert.False(t, called)
+	assert.ErrorIs(t, tr.Err(), context.Canceled)
+}
+
+func TestIdempotencyKeyWithoutStep(t *testing.T) {
+	// Act
+	key := IdempotencyKey(context.Background())
+
+	// Assert
+	assert.Empty(t, key)
+}
@@ -0,0 +1,28 @@
+package trier
+
+import "testing"
+
+// ForTest creates a new *Trier, configured with
+// any provided opts, for writing tests as chains.
+// Every error it records is annotated with the
+// step it came from (by name, or by index for
+// unnamed steps), a StepResult is kept for
+// every step, as with WithStepResults, and
+// once the test finishes, the test is failed
+// with the Trier's error, if it holds one
+func ForTest(tb testing.TB, opts ...Option) *Trier {
+	tb.Helper()
+
+	t := NewTrier(append([]Option{WithStepResults()}, opts...)...)
+	t.annotate = true
+
+	tb.Cleanup(func() {
+		tb.Helper()
+
+		if err := t.Err(); err != nil {
+			tb.Fatalf("trier chain failed:\n%v", err)
+		}
+	})
+
+	return t
+}
@@ -0,0 +1,81 @@
+package trier
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTB records what ForTest does with
+// a testing.TB instead of failing the
+// test that is testing it
+type fakeTB struct {
+	testing.TB
+	cleanups []func()
+	fatal    string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.fatal = fmt.Sprintf(format, args...)
+}
+
+func (f *fakeTB) finish() {
+	for _, fn := range f.cleanups {
+		fn()
+	}
+}
+
+func TestForTest(t *testing.T) {
+	// Arrange
+	tb := &fakeTB{}
+
+	// Act
+	ForTest(tb).TryFunc(pass).TryFunc(pass)
+	tb.finish()
+
+	// Assert
+	assert.Empty(t, tb.fatal)
+}
+
+func TestForTestFails(t *testing.T) {
+	// Arrange
+	tb := &fakeTB{}
+
+	// Act
+	tr := ForTest(tb).
+		TryFunc(pass).
+		TryFunc(fail)
+	tb.finish()
+
+	// Assert
+	assert.Equal(t, "trier chain failed:\nstep 1: failed fail", tb.fatal)
+	assert.Equal(t, "step 1: failed fail", tr.Err().Error())
+}
+
+func TestForTestNamedStep(t *testing.T) {
+	// Arrange
+	tb := &fakeTB{}
+
+	// Act
+	ForTest(tb, WithName("signup")).
+		TryStep(failingStep)
+	tb.finish()
+
+	// Assert
+	assert.Equal(t, "trier chain failed:\nsignup: failing: failed passOrFail", tb.fatal)
+}
+
+func TestForTestPasses(t *testing.T) {
+	// Act
+	tr := ForTest(t).TryFunc(pass)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+}
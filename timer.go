@@ -0,0 +1,38 @@
+package trier
+
+import (
+	"sync"
+	"time"
+)
+
+// timers pools the timers used for backoff
+// and timeouts, so services running many
+// retry loops at once don't churn through
+// a new timer for every wait
+var timers sync.Pool
+
+// getTimer returns a timer firing after d
+func getTimer(d time.Duration) *time.Timer {
+	if t, ok := timers.Get().(*time.Timer); ok {
+		// putTimer left its channel empty
+		t.Reset(d)
+		return t
+	}
+	return time.NewTimer(d)
+}
+
+// putTimer stops t and returns it to the pool.
+// Since Go 1.23, a stopped timer's channel is
+// always empty, but with asynctimerchan=1 it
+// may still hold the tick of a timer that fired
+// without being waited on, which would cut the
+// next wait short, so it's drained
+func putTimer(t *time.Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	timers.Put(t)
+}
@@ -0,0 +1,73 @@
+package trier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTimerReused(t *testing.T) {
+	// Arrange
+	first := getTimer(time.Hour)
+	putTimer(first)
+
+	// Act
+	timer := getTimer(time.Millisecond)
+	defer putTimer(timer)
+
+	// Assert
+	select {
+	case <-timer.C:
+	case <-time.After(time.Second):
+		t.Fatal("reused timer never fired")
+	}
+}
+
+func TestPutTimerAfterFiring(t *testing.T) {
+	// Arrange
+	fired := getTimer(0)
+	time.Sleep(time.Millisecond)
+	putTimer(fired)
+
+	// Act
+	timer := getTimer(time.Hour)
+	defer putTimer(timer)
+
+	// Assert
+	select {
+	case <-timer.C:
+		t.Fatal("reused timer fired early")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestTrierTryRetryBackoffNoTimerAllocs(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	attempt := 0
+
+	fn := func(args ...any) error {
+		attempt++
+		if attempt%2 == 1 {
+			return errBench
+		}
+		return nil
+	}
+
+	backoff := func(i int) time.Duration {
+		return time.Microsecond
+	}
+
+	// Act
+	tr.TryRetryBackoff(2, backoff, fn)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		tr.TryRetryBackoff(2, backoff, fn)
+	})
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.LessOrEqual(t, allocs, float64(2))
+}
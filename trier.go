@@ -2,19 +2,75 @@ package trier
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 )
 
-func NewTrier() *Trier {
-	return &Trier{}
+// NewTrier creates a new *Trier configured
+// with any provided opts
+func NewTrier(opts ...Option) *Trier {
+	t := &Trier{}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
 }
 
 // Trier internally keeps track of errors
 // and allows you to chain function calls
 // without having to keep track of whether
-// an error value is nil or not
+// an error value is nil or not. Trying
+// functions without args that succeed
+// with any of the Try methods that don't
+// take options doesn't allocate, so
+// a Trier can be used in hot loops
 type Trier struct {
-	err *error
+	// errs holds every recorded error, oldest
+	// first, to be joined once Err is called
+	errs []error
+	name string
+
+	// retain caps how many errors are kept,
+	// with dropped counting those that weren't
+	retain  int
+	dropped int
+
+	// firstOnly latches the first error,
+	// set by WithFirstErrorOnly
+	firstOnly bool
+
+	steps        int
+	deadline     time.Time
+	chainTimeout time.Duration
+	timedOut     bool
+
+	budget *ErrorBudget
+	shed   bool
+
+	errFn func(err error) error
+
+	annotate bool
+
+	// results holds a StepResult for every
+	// step tried, kept by WithStepResults
+	keepSteps bool
+	results   []StepResult
+
+	// strict is set by WithStrict
+	strict bool
+
+	// rnd is the seeded randomness
+	// set by WithDeterministic
+	rnd *source
+
+	// guarded is set by options every step
+	// must be checked against (such as
+	// WithChainTimeout), ruling out the
+	// inlined fast path in Try
+	guarded bool
 }
 
 // Try checks for an existing error and if
@@ -26,80 +82,31 @@ type Trier struct {
 // may exist, and you want to collect multiple
 // errors, use TryWrap() instead
 func (t *Trier) Try(fn func(args ...any) error, args ...any) *Trier {
-	if t.err != nil {
+	// kept small enough to be inlined, so
+	// skipping steps once a chain has failed
+	// costs no more than checking by hand
+	if t.failed() {
 		return t
 	}
 
-	err := fn(args...)
-
-	if err != nil {
-		if t.err == nil {
-			t.err = &err
-		} else {
-			*t.err = err
-		}
-	}
-
-	return t
+	return t.tryOnce(fn, args)
 }
 
 // TryIfErr is like Try, but if an error occurs, passes it to errFn before returning
 func (t *Trier) TryIfErr(errFn func(err error) error, fn func(args ...any) error, args ...any) *Trier {
-	if t.err != nil {
-		return t
-	}
-
-	err := fn(args...)
-
-	if err != nil {
-		if t.err == nil {
-			t.err = &err
-		} else {
-			*t.err = err
-		}
-	}
-
-	if t.err != nil {
-		err := errFn(*t.err)
-		t.err = &err
-	}
-	return t
+	return t.tryFast(fn, tryConfig{limit: 1, args: args, errFn: errFn, guards: guardErrFn})
 }
 
 // TryRetry is a fault-tolerant version of Try.
 // If fn returns an error, it will retry to run
 // fn up to limit times. If limit is less than or
 // equal to zero, TryRetry will continually retry
-// running fn until it doesn't error
+// running fn until it doesn't error. If every
+// attempt fails, the errors from each attempt
+// are joined newest first, with consecutive ones
+// that match folded into a *RepeatedError
 func (t *Trier) TryRetry(limit int, fn func(args ...any) error, args ...any) *Trier {
-	if t.err != nil {
-		return t
-	}
-
-	switch limit <= 0 {
-	case true:
-		for {
-			err := fn(args...)
-			if err == nil {
-				break
-			}
-		}
-	case false:
-		for i := 0; i < limit; i++ {
-			err := fn(args...)
-			if err == nil {
-				break
-			}
-
-			if t.err != nil {
-				*t.err = errors.Join(*t.err, err)
-			} else {
-				*t.err = err
-			}
-		}
-	}
-
-	return t
+	return t.tryFast(fn, tryConfig{limit: limit, args: args})
 }
 
 // TryRetryIfErr is just a combination
@@ -109,72 +116,20 @@ func (t *Trier) TryRetry(limit int, fn func(args ...any) error, args ...any) *Tr
 // be passes to errFn before being joined
 // with previous errors
 func (t *Trier) TryRetryIfErr(limit int, errFn func(err error) error, fn func(args ...any) error, args ...any) *Trier {
-	if t.err != nil {
-		return t
-	}
-
-	switch limit <= 0 {
-	case true:
-		for {
-			err := fn(args...)
-			if err == nil {
-				break
-			}
-		}
-	case false:
-		for i := 0; i < limit; i++ {
-			err := fn(args...)
-			if err == nil {
-				break
-			}
-
-			if t.err != nil {
-				*t.err = errors.Join(*t.err, errFn(err))
-			} else {
-				*t.err = err
-			}
-		}
-	}
-
-	return t
+	return t.tryFast(fn, tryConfig{limit: limit, args: args, errFn: errFn, guards: guardErrFn})
 }
 
 // TryRetryBackoff is similar to TryRetry,
 // except if limit is less than or equal
-// to zero, it will create a new error with
-// the value "retry backoff attempted with
-// limit less than or equal to zero" and
+// to zero, it will record ErrInvalidLimit
+// (or ErrNilBackoff if backoff is nil) and
 // immediately return. Otherwise, it will
 // run just like TryRetry with the added
 // step of waiting for the time.Duration
 // returned by the provided backoff func
 // before retrying on an error
 func (t *Trier) TryRetryBackoff(limit int, backoff func(i int) time.Duration, fn func(args ...any) error, args ...any) *Trier {
-	if t.err != nil {
-		return t
-	}
-
-	switch limit <= 0 {
-	case true:
-		*t.err = errors.New("retry backoff attempted with limit less than or equal to zero")
-	case false:
-		for i := 0; i < limit; i++ {
-			err := fn(args...)
-			if err == nil {
-				break
-			}
-
-			if t.err != nil {
-				*t.err = errors.Join(*t.err, err)
-			} else {
-				*t.err = err
-			}
-
-			time.Sleep(backoff(i))
-		}
-	}
-
-	return t
+	return t.tryFast(fn, tryConfig{limit: limit, args: args, backoff: backoff, guards: guardBackoff})
 }
 
 // TryRetryBackoffIfErr is just a combination
@@ -183,63 +138,262 @@ func (t *Trier) TryRetryBackoff(limit int, backoff func(i int) time.Duration, fn
 // is returned, it will first be passes to
 // errFn before being joined with any previous errors
 func (t *Trier) TryRetryBackoffIfErr(limit int, errFn func(err error) error, backoff func(i int) time.Duration, fn func(args ...any) error, args ...any) *Trier {
-	if t.err != nil {
+	return t.tryFast(fn, tryConfig{limit: limit, args: args, backoff: backoff, errFn: errFn, guards: guardBackoff | guardErrFn})
+}
+
+// TryJoin calls fn with the given args and
+// if a previous error exists and fn returns
+// an error, it will join these two errors
+// together with errors.Join() to allow for
+// multiple errors to be collected. Errors
+// are joined newest first, so the error
+// from fn comes before earlier ones. Only
+// errors are recorded, so fn succeeding
+// leaves any previous error as it was
+func (t *Trier) TryJoin(fn func(args ...any) error, args ...any) *Trier {
+	return t.tryFast(fn, tryConfig{limit: 1, args: args, join: true})
+}
+
+// TryMulti is like Try, but for fn returning
+// several independent errors at once (such as
+// a validator), joining every one of them
+// that isn't nil together with errors.Join.
+// If none of them are errors, fn succeeded
+func (t *Trier) TryMulti(fn func(args ...any) []error, args ...any) *Trier {
+	if t.failed() {
 		return t
 	}
 
-	switch limit <= 0 {
-	case true:
-		*t.err = errors.New("retry backoff attempted with limit less than or equal to zero")
-	case false:
-		for i := 0; i < limit; i++ {
-			err := fn(args...)
-			if err == nil {
-				break
-			}
+	// a nil fn is left nil for
+	// try to record ErrNilFunc
+	var try func(args ...any) error
+	if fn != nil {
+		try = func(args ...any) error {
+			return errors.Join(fn(args...)...)
+		}
+	}
 
-			if t.err != nil {
-				*t.err = errors.Join(*t.err, errFn(err))
-			} else {
-				*t.err = err
-			}
+	return t.tryOnce(try, args)
+}
+
+// TryOk is like Try, but for fn reporting
+// whether it found (or finished) what it was
+// after alongside any error, recording
+// notOkErr if fn reports !ok without an
+// error, such as for "not found" or "not
+// ready" results. With a nil notOkErr,
+// fn reporting !ok isn't a failure
+func (t *Trier) TryOk(fn func(args ...any) (bool, error), notOkErr error, args ...any) *Trier {
+	if t.failed() {
+		return t
+	}
 
-			time.Sleep(backoff(i))
+	// a nil fn is left nil for
+	// try to record ErrNilFunc
+	var try func(args ...any) error
+	if fn != nil {
+		try = func(args ...any) error {
+			ok, err := fn(args...)
+			if err == nil && !ok {
+				return notOkErr
+			}
+			return err
 		}
 	}
 
+	return t.tryOnce(try, args)
+}
+
+// tryFast tries fn with c built directly,
+// rather than from options, so c can stay
+// on the stack and trying fn doesn't
+// allocate unless it fails
+func (t *Trier) tryFast(fn func(args ...any) error, c tryConfig) *Trier {
+	t.try(fn, &c)
 	return t
 }
 
-// TryJoin calls fn with the given args and
-// if a previous error exists and fn returns
-// an error, it will join these two errors
-// together with errors.Join() to allow for
-// multiple errors to be collected
-func (t *Trier) TryJoin(fn func(args ...any) error, args ...any) *Trier {
-	err := fn(args...)
+// tryOnce is the fast path behind Try, calling
+// fn directly unless an option (like
+// WithChainTimeout) needs the full try path,
+// or fn is nil and has to be checked by it
+func (t *Trier) tryOnce(fn func(args ...any) error, args []any) *Trier {
+	if t.guarded || fn == nil {
+		return t.tryFast(fn, tryConfig{limit: 1, args: args})
+	}
+
+	step := t.steps
+	t.steps++
 
-	if t.err != nil {
-		x := errors.Join(*t.err, err)
-		t.err = &x
-	} else {
-		t.err = &err
+	if err := fn(args...); err != nil {
+		if t.annotate {
+			err = &StepError{Index: step, Err: err}
+		}
+
+		t.record(err)
 	}
 
 	return t
 }
 
+// record stores err after passing it through
+// any errFn set by WithErrFn, returning err
+// as it was recorded
+func (t *Trier) record(err error) error {
+	if err != nil && t.errFn != nil {
+		err = t.errFn(err)
+	}
+
+	t.store(err)
+
+	return err
+}
+
+// store adds err to the errors
+// already held by the Trier
+func (t *Trier) store(err error) {
+	if err == nil {
+		return
+	}
+
+	if t.firstOnly && len(t.errs) != 0 {
+		return
+	}
+
+	if t.retain > 0 && len(t.errs) >= t.retain {
+		t.dropped++
+		return
+	}
+
+	t.errs = append(t.errs, err)
+}
+
+// failed reports whether the
+// Trier holds any error
+func (t *Trier) failed() bool {
+	return len(t.errs) != 0
+}
+
+// joined joins every error held by
+// the Trier, newest first, into one
+func (t *Trier) joined() error {
+	switch {
+	case len(t.errs) == 0:
+		return nil
+	case len(t.errs) == 1 && t.dropped == 0:
+		return t.errs[0]
+	}
+
+	errs := make([]error, 0, len(t.errs)+1)
+
+	if t.dropped > 0 {
+		errs = append(errs, &DroppedError{Count: t.dropped})
+	}
+
+	for i := len(t.errs) - 1; i >= 0; i-- {
+		errs = append(errs, t.errs[i])
+	}
+
+	return errors.Join(errs...)
+}
+
+// DroppedError is joined in front of the
+// errors kept by a Trier configured with
+// WithErrorRetention, counting the
+// errors recorded after those
+type DroppedError struct {
+	Count int
+}
+
+func (e *DroppedError) Error() string {
+	return fmt.Sprintf("%d more errors dropped", e.Count)
+}
+
+// Dropped returns how many errors weren't
+// kept because of WithErrorRetention
+func (t *Trier) Dropped() int {
+	return t.dropped
+}
+
 // Nil allows you to nil out an error. This way a
 // single trier can be used across a codebase as
 // long as you know when you are nilling out errors
 func (t *Trier) Nil() *Trier {
-	if t.err != nil {
-		t.err = nil
-	}
+	t.errs = nil
+	t.dropped = 0
+	t.shed = false
 	return t
 }
 
+// NilErr is like Nil, but returns the
+// error being cleared (as Err would),
+// so it can be handled rather than
+// silently thrown away
+func (t *Trier) NilErr() error {
+	err := t.Err()
+	t.Nil()
+	return err
+}
+
+// Steps returns a StepResult for every step
+// the Trier has tried, in the order they were
+// tried, if it was created WithStepResults
+// (or by ForTest). Steps that weren't tried,
+// since the Trier already held an error,
+// aren't included
+func (t *Trier) Steps() []StepResult {
+	return slices.Clone(t.results)
+}
+
+// Name returns the name given to the
+// Trier with WithName, if any
+func (t *Trier) Name() string {
+	return t.name
+}
+
 // Err returns the first error experienced,
-// or any wrapped errors
+// or any wrapped errors, joined newest
+// first. If the Trier was named with
+// WithName, the error is prefixed
+// with that name
 func (t *Trier) Err() error {
-	return *t.err
+	err := t.joined()
+	if err == nil || t.name == "" {
+		return err
+	}
+
+	return fmt.Errorf("%s: %w", t.name, err)
+}
+
+// Error makes *Trier satisfy error, returning
+// the message of the error returned by Err, or
+// an empty string if there is none. Returning
+// a *Trier as an error yields a non-nil error
+// even when it doesn't hold one, so use
+// AsError when that matters
+func (t *Trier) Error() string {
+	err := t.Err()
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// Unwrap returns the error returned by Err,
+// so errors.Is and errors.As see through a
+// *Trier used as an error
+func (t *Trier) Unwrap() error {
+	return t.Err()
+}
+
+// AsError returns t as an error if it holds
+// one, or nil if it doesn't, so functions
+// that only speak error can `return
+// tr.AsError()` without the nil-interface
+// pitfall of returning t directly
+func (t *Trier) AsError() error {
+	if t.Err() == nil {
+		return nil
+	}
+	return t
 }
@@ -2,6 +2,7 @@ package trier
 
 import (
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -39,7 +40,7 @@ func TestTrierTry(t *testing.T) {
 	tr.Try(passOrFail)
 
 	// Assert
-	assert.Nil(t, tr.err)
+	assert.Empty(t, tr.errs)
 }
 
 func TestTrierTryError(t *testing.T) {
@@ -50,7 +51,7 @@ func TestTrierTryError(t *testing.T) {
 	tr.Try(passOrFail, true)
 
 	// Assert
-	x := *tr.err
+	x := tr.Err()
 	assert.Equal(t, "failed passOrFail", x.Error())
 }
 
@@ -65,7 +66,7 @@ func TestTrierTryErrorChainedTries(t *testing.T) {
 		Try(failIfString, "hi")
 
 	// Assert
-	x := *tr.err
+	x := tr.Err()
 	assert.Equal(t, "failed passOrFail", x.Error())
 }
 
@@ -80,7 +81,7 @@ func TestTrierTryErrorWrapped(t *testing.T) {
 		TryJoin(failIfString, "hi")
 
 	// Assert
-	x := *tr.err
+	x := tr.Err()
 	assert.Equal(t, "failedIfString\nfailed passOrFail", x.Error())
 }
 
@@ -123,6 +124,23 @@ func TestTrierErrJoined(t *testing.T) {
 	assert.Equal(t, "failedIfString\nfailed passOrFail", tr.Err().Error())
 }
 
+func TestTrierErrJoinedNewestFirst(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryJoin(failIfString, "first").
+		TryJoin(failIfString, "second").
+		TryJoin(passOrFail, true)
+
+	// Assert
+	assert.Equal(t, "failed passOrFail\nfailedIfString\nfailedIfString", tr.Err().Error())
+
+	var joined interface{ Unwrap() []error }
+	assert.ErrorAs(t, tr.Err(), &joined)
+	assert.Equal(t, "failed passOrFail", joined.Unwrap()[0].Error())
+}
+
 func TestTrierTryJoinNoPreviousError(t *testing.T) {
 	// Arrange
 	tr := NewTrier()
@@ -149,6 +167,129 @@ func TestTrierTryJoinNoErrors(t *testing.T) {
 	assert.Nil(t, tr.Err())
 }
 
+func TestTrierTryJoinNilAfterError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryJoin(passOrFail, true).
+		TryJoin(passOrFail).
+		TryJoin(failIfString, "hi")
+
+	// Assert
+	assert.Equal(t, "failedIfString\nfailed passOrFail", tr.Err().Error())
+	assert.Len(t, tr.errs, 2)
+}
+
+func TestTrierTryJoinErrorAfterNil(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryJoin(passOrFail).
+		TryJoin(failIfString, 0).
+		TryJoin(passOrFail, true)
+
+	// Assert
+	assert.Equal(t, "failed passOrFail", tr.Err().Error())
+	assert.Len(t, tr.errs, 1)
+}
+
+func validate(args ...any) []error {
+	var errs []error
+	for _, arg := range args {
+		if s, ok := arg.(string); !ok || s == "" {
+			errs = append(errs, fmt.Errorf("invalid %v", arg))
+		}
+	}
+	return errs
+}
+
+func TestTrierTryMulti(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryMulti(validate, "a", "b").
+		TryMulti(validate, "a", 1, "", nil)
+
+	// Assert
+	assert.Equal(t, "invalid 1\ninvalid \ninvalid <nil>", tr.Err().Error())
+}
+
+func TestTrierTryMultiNil(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryMulti(func(args ...any) []error {
+		return []error{nil, nil}
+	}).
+		TryMulti(nil)
+
+	// Assert
+	assert.Equal(t, &StepError{Index: 1, Err: ErrNilFunc}, tr.Err())
+}
+
+var errNotReady = errors.New("not ready")
+
+func lookup(args ...any) (bool, error) {
+	switch args[0] {
+	case "ready":
+		return true, nil
+	case "broken":
+		return true, errors.New("broken")
+	}
+	return false, nil
+}
+
+func TestTrierTryOk(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryOk(lookup, errNotReady, "ready").
+		TryOk(lookup, errNotReady, "pending")
+
+	// Assert
+	assert.Equal(t, errNotReady, tr.Err())
+}
+
+func TestTrierTryOkErr(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryOk(lookup, errNotReady, "broken")
+
+	// Assert
+	assert.Equal(t, "broken", tr.Err().Error())
+}
+
+func TestTrierTryOkNilNotOkErr(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryOk(lookup, nil, "pending")
+
+	// Assert
+	assert.Nil(t, tr.Err())
+}
+
+func TestTrierNilErr(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithName("loader"))
+
+	// Act
+	err := tr.Try(passOrFail, true).NilErr()
+
+	// Assert
+	assert.Equal(t, "loader: failed passOrFail", err.Error())
+	assert.Nil(t, tr.Err())
+	assert.Nil(t, tr.Try(passOrFail).NilErr())
+}
+
 func TestTrierAnonymousFunc(t *testing.T) {
 	// Arrange
 	tr := NewTrier()
@@ -164,3 +305,102 @@ func TestTrierAnonymousFunc(t *testing.T) {
 	// Assert
 	assert.Equal(t, "hello", x)
 }
+
+func TestTrierError(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithName("chain"))
+
+	// Act
+	tr.Try(passOrFail, true)
+
+	// Assert
+	var err error = tr
+	assert.Equal(t, "chain: failed passOrFail", err.Error())
+}
+
+func TestTrierErrorNoError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.Try(passOrFail)
+
+	// Assert
+	assert.Equal(t, "", tr.Error())
+}
+
+func TestTrierUnwrap(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	errFailed := errors.New("failed")
+
+	// Act
+	tr.Try(func(args ...any) error {
+		return errFailed
+	})
+
+	// Assert
+	assert.ErrorIs(t, tr, errFailed)
+}
+
+func TestTrierAsError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.Try(passOrFail, true)
+
+	// Assert
+	err := tr.AsError()
+	assert.Same(t, tr, err)
+	assert.Equal(t, "failed passOrFail", err.Error())
+}
+
+func TestTrierAsErrorNoError(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.Try(passOrFail)
+
+	// Assert
+	assert.Nil(t, tr.AsError())
+}
+
+func TestTrierTryNoAllocs(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	errFn := func(err error) error {
+		return err
+	}
+
+	// Act
+	allocs := testing.AllocsPerRun(100, func() {
+		tr.Try(nop).
+			TryIfErr(errFn, nop).
+			TryRetry(3, nop).
+			TryRetryIfErr(3, errFn, nop).
+			TryJoin(nop)
+	})
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Zero(t, allocs)
+}
+
+func TestTrierErrFlat(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	for i := 0; i < 5; i++ {
+		tr.TryJoin(passOrFail, true)
+	}
+
+	// Assert
+	joined, ok := tr.Err().(interface{ Unwrap() []error })
+	assert.True(t, ok)
+	assert.Len(t, joined.Unwrap(), 5)
+}
@@ -0,0 +1,131 @@
+// Package triertest provides assertions for
+// testing code built on Triers, checking which
+// step failed, with what and after how many
+// attempts, rather than comparing joined error
+// strings that break whenever formatting changes
+package triertest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/syke99/trier"
+)
+
+// AssertFailedStep fails the test unless tr
+// holds an error from the step named step.
+// Steps without a name (such as those
+// annotated by trier.ForTest) are named
+// by index, as in "step 2"
+func AssertFailedStep(tb testing.TB, tr *trier.Trier, step string) bool {
+	tb.Helper()
+
+	if findStep(tr.Err(), step) == nil {
+		tb.Errorf("expected step %q to have failed, but %s", step, describe(tr))
+		return false
+	}
+
+	return true
+}
+
+// AssertErrIs fails the test unless any
+// error held by tr matches target, as
+// reported by errors.Is
+func AssertErrIs(tb testing.TB, tr *trier.Trier, target error) bool {
+	tb.Helper()
+
+	if !errors.Is(tr.Err(), target) {
+		tb.Errorf("expected an error matching %q, but %s", target, describe(tr))
+		return false
+	}
+
+	return true
+}
+
+// AssertAttempts fails the test unless the
+// step named step (as for AssertFailedStep)
+// made exactly n attempts. Steps that
+// succeeded can be checked too if tr keeps
+// step results, as a Trier from trier.ForTest
+// or made with trier.WithStepResults does
+func AssertAttempts(tb testing.TB, tr *trier.Trier, step string, n int) bool {
+	tb.Helper()
+
+	attempts, ok := stepAttempts(tr, step)
+	if !ok {
+		tb.Errorf("expected step %q to have made %d attempts, but it wasn't tried and %s", step, n, describe(tr))
+		return false
+	}
+
+	if attempts != n {
+		tb.Errorf("expected step %q to have made %d attempts, but it made %d", step, n, attempts)
+		return false
+	}
+
+	return true
+}
+
+// stepAttempts returns how many attempts
+// step made, from the StepResults tr keeps
+// or, failing that, the error it recorded
+func stepAttempts(tr *trier.Trier, step string) (int, bool) {
+	results := tr.Steps()
+	for i := len(results) - 1; i >= 0; i-- {
+		if label(results[i].Index, results[i].Name) == step {
+			return results[i].Attempts, true
+		}
+	}
+
+	se := findStep(tr.Err(), step)
+	if se == nil {
+		return 0, false
+	}
+
+	if re, ok := trier.RetryInfo(se.Err); ok {
+		return re.Attempts, true
+	}
+
+	return 1, true
+}
+
+// findStep walks every error in err,
+// including those joined together, for
+// the *trier.StepError of step
+func findStep(err error, step string) *trier.StepError {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *trier.StepError:
+		if label(e.Index, e.Name) == step {
+			return e
+		}
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if se := findStep(err, step); se != nil {
+				return se
+			}
+		}
+		return nil
+	}
+
+	return findStep(errors.Unwrap(err), step)
+}
+
+// label names a step the way its
+// error message does
+func label(index int, name string) string {
+	if name == "" {
+		return fmt.Sprintf("step %d", index)
+	}
+	return name
+}
+
+// describe tells what tr holds
+// for failure messages
+func describe(tr *trier.Trier) string {
+	if tr.Err() == nil {
+		return "the Trier holds no error"
+	}
+	return fmt.Sprintf("the Trier holds:\n%+v", tr)
+}
@@ -0,0 +1,153 @@
+package triertest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+var errFlaky = errors.New("flaky")
+
+func flaky(args ...any) error {
+	return errFlaky
+}
+
+func ok(args ...any) error {
+	return nil
+}
+
+// recorder stands in for the testing.TB
+// passed to assertions, keeping the
+// failures they report
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func failed() *trier.Trier {
+	tr := trier.NewTrier()
+
+	tr.TryWith(ok, trier.WithStepName("load")).
+		TryWith(flaky, trier.WithStepName("save"), trier.WithRetry(3)).
+		TryJoin(flaky)
+
+	return tr
+}
+
+func TestAssertFailedStep(t *testing.T) {
+	// Arrange
+	r := &recorder{TB: t}
+	tr := failed()
+
+	// Act
+	passed := AssertFailedStep(r, tr, "save")
+	missing := AssertFailedStep(r, tr, "load")
+
+	// Assert
+	assert.True(t, passed)
+	assert.False(t, missing)
+	assert.Len(t, r.failures, 1)
+	assert.Contains(t, r.failures[0], `expected step "load" to have failed`)
+}
+
+func TestAssertFailedStepByIndex(t *testing.T) {
+	// Arrange
+	r := &recorder{TB: t}
+	tr := trier.ForTest(t)
+
+	tr.Try(ok).
+		Try(flaky)
+
+	// Act
+	passed := AssertFailedStep(r, tr, "step 1")
+
+	// Assert
+	assert.True(t, passed)
+	assert.Empty(t, r.failures)
+
+	tr.Nil()
+}
+
+func TestAssertErrIs(t *testing.T) {
+	// Arrange
+	r := &recorder{TB: t}
+	tr := failed()
+
+	// Act
+	passed := AssertErrIs(r, tr, errFlaky)
+	missing := AssertErrIs(r, trier.NewTrier(), errFlaky)
+
+	// Assert
+	assert.True(t, passed)
+	assert.False(t, missing)
+	assert.Equal(t, []string{`expected an error matching "flaky", but the Trier holds no error`}, r.failures)
+}
+
+func TestAssertAttempts(t *testing.T) {
+	// Arrange
+	r := &recorder{TB: t}
+	tr := failed()
+
+	// Act
+	passed := AssertAttempts(r, tr, "save", 3)
+	wrong := AssertAttempts(r, tr, "save", 2)
+
+	// Assert
+	assert.True(t, passed)
+	assert.False(t, wrong)
+	assert.Equal(t, []string{`expected step "save" to have made 2 attempts, but it made 3`}, r.failures)
+}
+
+func TestAssertAttemptsOnce(t *testing.T) {
+	// Arrange
+	r := &recorder{TB: t}
+	tr := trier.NewTrier()
+
+	tr.TryWith(flaky, trier.WithStepName("save"))
+
+	// Act
+	passed := AssertAttempts(r, tr, "save", 1)
+
+	// Assert
+	assert.True(t, passed)
+	assert.Empty(t, r.failures)
+}
+
+func TestAssertAttemptsSucceeded(t *testing.T) {
+	// Arrange
+	r := &recorder{TB: t}
+	tr := trier.NewTrier(trier.WithStepResults())
+
+	attempt := 0
+
+	tr.TryWith(func(args ...any) error {
+		attempt++
+		if attempt < 3 {
+			return errFlaky
+		}
+		return nil
+	}, trier.WithStepName("save"), trier.WithRetry(5)).
+		TryWith(ok, trier.WithStepName("load"))
+
+	// Act
+	passed := AssertAttempts(r, tr, "save", 3)
+	once := AssertAttempts(r, tr, "load", 1)
+	missing := AssertAttempts(r, tr, "send", 1)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.True(t, passed)
+	assert.True(t, once)
+	assert.False(t, missing)
+	assert.Len(t, r.failures, 1)
+	assert.Contains(t, r.failures[0], `expected step "send" to have made 1 attempts, but it wasn't tried`)
+}
@@ -0,0 +1,82 @@
+// Package errgroupcompat eases migrating code
+// built on golang.org/x/sync/errgroup to trier.
+// Its Group has the same methods as an
+// errgroup.Group, so it can be swapped in
+// without touching the code that uses it,
+// while folding the group's error into a Trier
+package errgroupcompat
+
+import (
+	"context"
+
+	"github.com/syke99/trier"
+	"golang.org/x/sync/errgroup"
+)
+
+// Group is an errgroup.Group whose Wait
+// also records its error into a Trier
+type Group struct {
+	g  *errgroup.Group
+	tr *trier.Trier
+}
+
+// NewGroup creates a new *Group
+// recording its error into tr
+func NewGroup(tr *trier.Trier) *Group {
+	return &Group{g: &errgroup.Group{}, tr: tr}
+}
+
+// WithContext is like errgroup.WithContext,
+// but the returned *Group records its
+// error into tr
+func WithContext(ctx context.Context, tr *trier.Trier) (*Group, context.Context) {
+	g, ctx := errgroup.WithContext(ctx)
+	return &Group{g: g, tr: tr}, ctx
+}
+
+// Go calls fn in a new goroutine,
+// just like (*errgroup.Group).Go
+func (g *Group) Go(fn func() error) {
+	g.g.Go(fn)
+}
+
+// TryGo is like (*errgroup.Group).TryGo
+func (g *Group) TryGo(fn func() error) bool {
+	return g.g.TryGo(fn)
+}
+
+// SetLimit is like (*errgroup.Group).SetLimit
+func (g *Group) SetLimit(n int) {
+	g.g.SetLimit(n)
+}
+
+// Wait waits for every goroutine started by
+// the Group, returning the first error any
+// of them returned just like errgroup does,
+// and recording that error into the Trier
+func (g *Group) Wait() error {
+	err := g.g.Wait()
+
+	g.tr.Record(err)
+
+	return err
+}
+
+// Group returns the underlying
+// *errgroup.Group
+func (g *Group) Group() *errgroup.Group {
+	return g.g
+}
+
+// FromGroup waits for an existing
+// *errgroup.Group, recording its
+// error into tr
+func FromGroup(tr *trier.Trier, g *errgroup.Group) *trier.Trier {
+	// a nil *errgroup.Group would make
+	// a non-nil trier.Waiter
+	if g == nil {
+		return tr.FromGroup(nil)
+	}
+
+	return tr.FromGroup(g)
+}
@@ -0,0 +1,96 @@
+package errgroupcompat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+	"golang.org/x/sync/errgroup"
+)
+
+func TestNewGroup(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	g := NewGroup(tr)
+
+	// Act
+	g.Go(func() error {
+		return nil
+	})
+	err := g.Wait()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, tr.Err())
+}
+
+func TestGroupWaitRecordsError(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	g := NewGroup(tr)
+
+	errFailed := errors.New("failed")
+
+	// Act
+	g.Go(func() error {
+		return errFailed
+	})
+	err := g.Wait()
+
+	// Assert
+	assert.Equal(t, errFailed, err)
+	assert.ErrorIs(t, tr.Err(), errFailed)
+}
+
+func TestWithContext(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	g, ctx := WithContext(context.Background(), tr)
+
+	// Act
+	g.Go(func() error {
+		return errors.New("failed")
+	})
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	err := g.Wait()
+
+	// Assert
+	assert.Equal(t, "failed", err.Error())
+	assert.Equal(t, "failed", tr.Err().Error())
+}
+
+func TestFromGroup(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	var g errgroup.Group
+
+	g.Go(func() error {
+		return errors.New("failed")
+	})
+
+	// Act
+	FromGroup(tr, &g)
+
+	// Assert
+	assert.Equal(t, "failed", tr.Err().Error())
+}
+
+func TestFromGroupNil(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	// Act
+	FromGroup(tr, nil)
+
+	// Assert
+	assert.Equal(t, trier.ErrNilGroup, tr.Err())
+}
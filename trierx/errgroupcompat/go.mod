@@ -0,0 +1,17 @@
+module github.com/syke99/trier/trierx/errgroupcompat
+
+go 1.23.0
+
+require (
+	github.com/stretchr/testify v1.8.4
+	github.com/syke99/trier v0.0.0-00010101000000-000000000000
+	golang.org/x/sync v0.16.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/syke99/trier => ../..
@@ -0,0 +1,74 @@
+// Package trierexec retries external commands
+// with trier's retry engine
+package trierexec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/syke99/trier"
+)
+
+// CommandError is returned for an attempt
+// at running a command that failed, carrying
+// everything the command wrote to stdout
+// and stderr
+type CommandError struct {
+	Args   []string
+	Output []byte
+	Err    error
+}
+
+func (e *CommandError) Error() string {
+	msg := fmt.Sprintf("%s: %s", strings.Join(e.Args, " "), e.Err)
+
+	if out := bytes.TrimSpace(e.Output); len(out) != 0 {
+		msg = fmt.Sprintf("%s\n%s", msg, out)
+	}
+
+	return msg
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the command's exit code,
+// or -1 if it didn't exit normally
+func (e *CommandError) ExitCode() int {
+	var exitErr *exec.ExitError
+	if errors.As(e.Err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// TryCommand runs the command returned by build
+// through tr with policy. Since an *exec.Cmd can
+// only be run once, build is called again for
+// every attempt, and it must leave the command's
+// Stdout and Stderr unset so they can be captured.
+// The combined output of the successful attempt
+// is returned, and that of every failed attempt
+// is kept in the *CommandError recorded for it
+func TryCommand(tr *trier.Trier, policy trier.RetryPolicy, build func() *exec.Cmd) []byte {
+	var out []byte
+
+	tr.TryWith(func(args ...any) error {
+		cmd := build()
+
+		b, err := cmd.CombinedOutput()
+		if err != nil {
+			return &CommandError{Args: cmd.Args, Output: b, Err: err}
+		}
+
+		out = b
+
+		return nil
+	}, trier.WithPolicy(policy))
+
+	return out
+}
@@ -0,0 +1,83 @@
+package trierexec
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+var fastPolicy = trier.RetryPolicy{
+	Attempts: 3,
+	Backoff: func(i int) time.Duration {
+		return time.Millisecond
+	},
+}
+
+func sh(script string) func() *exec.Cmd {
+	return func() *exec.Cmd {
+		return exec.Command("sh", "-c", script)
+	}
+}
+
+func TestTryCommand(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	// Act
+	out := TryCommand(tr, fastPolicy, sh("echo hello"))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, "hello\n", string(out))
+}
+
+func TestTryCommandRetries(t *testing.T) {
+	// Arrange
+	marker := filepath.Join(t.TempDir(), "marker")
+
+	tr := trier.NewTrier()
+
+	// Act
+	out := TryCommand(tr, fastPolicy, sh("test -f "+marker+" || { touch "+marker+"; echo not yet; exit 1; }; echo done"))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, "done\n", string(out))
+}
+
+func TestTryCommandExhausted(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	// Act
+	out := TryCommand(tr, trier.RetryPolicy{Attempts: 1}, sh("echo broken >&2; exit 3"))
+
+	// Assert
+	assert.Nil(t, out)
+
+	var cmdErr *CommandError
+	assert.True(t, errors.As(tr.Err(), &cmdErr))
+	assert.Equal(t, 3, cmdErr.ExitCode())
+	assert.Equal(t, "sh -c echo broken >&2; exit 3: exit status 3\nbroken", cmdErr.Error())
+}
+
+func TestTryCommandNotFound(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	// Act
+	TryCommand(tr, trier.RetryPolicy{Attempts: 1}, func() *exec.Cmd {
+		return exec.Command("trierexec-does-not-exist")
+	})
+
+	// Assert
+	var cmdErr *CommandError
+	assert.True(t, errors.As(tr.Err(), &cmdErr))
+	assert.Equal(t, -1, cmdErr.ExitCode())
+	assert.ErrorIs(t, tr.Err(), exec.ErrNotFound)
+}
@@ -0,0 +1,8 @@
+//go:build !unix && !windows
+
+package trierfs
+
+// transientErrnos is empty on platforms
+// without well known transient errors,
+// so nothing is retried by default
+var transientErrnos []error
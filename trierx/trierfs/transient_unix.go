@@ -0,0 +1,16 @@
+//go:build unix
+
+package trierfs
+
+import "syscall"
+
+// transientErrnos are the errors worth retrying:
+// a busy file or device, a resource that is
+// temporarily unavailable, an interrupted
+// call, or an executable that is being run
+var transientErrnos = []error{
+	syscall.EBUSY,
+	syscall.EAGAIN,
+	syscall.EINTR,
+	syscall.ETXTBSY,
+}
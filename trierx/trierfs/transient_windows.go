@@ -0,0 +1,22 @@
+//go:build windows
+
+package trierfs
+
+import "syscall"
+
+// Windows error codes not exported by syscall
+const (
+	errorSharingViolation syscall.Errno = 32
+	errorLockViolation    syscall.Errno = 33
+)
+
+// transientErrnos are the errors worth retrying.
+// On Windows, another process (often a virus
+// scanner or indexer) briefly holding a file
+// open surfaces as a sharing or lock violation,
+// or even as access being denied
+var transientErrnos = []error{
+	errorSharingViolation,
+	errorLockViolation,
+	syscall.ERROR_ACCESS_DENIED,
+}
@@ -0,0 +1,88 @@
+// Package trierfs provides filesystem helpers that
+// retry operations failing with transient OS errors
+// (such as a file being busy, or another process
+// holding it open on Windows) with trier's retry
+// engine, while failing fast on permanent ones
+package trierfs
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/syke99/trier"
+)
+
+// DefaultPolicy is used when no
+// TryOptions are given
+var DefaultPolicy = trier.RetryPolicy{
+	Attempts: 5,
+	Backoff:  trier.ExponentialBackoff(10*time.Millisecond, time.Second),
+	Jitter:   0.2,
+}
+
+// IsTransient reports whether err is an OS
+// error likely to go away if retried. What
+// counts as transient depends on the
+// platform, see transientErrnos
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, errno := range transientErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// options applies DefaultPolicy if opts is
+// empty, and IsTransient as the default
+// retry predicate, so any given WithRetryIf
+// replaces it
+func options(opts []trier.TryOption) []trier.TryOption {
+	if len(opts) == 0 {
+		opts = []trier.TryOption{trier.WithPolicy(DefaultPolicy)}
+	}
+
+	return append([]trier.TryOption{trier.WithRetryIf(IsTransient)}, opts...)
+}
+
+// TryRemoveAll is os.RemoveAll tried through tr,
+// retrying transient failures with opts, or
+// DefaultPolicy if no opts are given
+func TryRemoveAll(tr *trier.Trier, path string, opts ...trier.TryOption) *trier.Trier {
+	return tr.TryWith(func(args ...any) error {
+		return os.RemoveAll(path)
+	}, options(opts)...)
+}
+
+// TryRename is os.Rename tried through tr,
+// just like TryRemoveAll
+func TryRename(tr *trier.Trier, oldpath, newpath string, opts ...trier.TryOption) *trier.Trier {
+	return tr.TryWith(func(args ...any) error {
+		return os.Rename(oldpath, newpath)
+	}, options(opts)...)
+}
+
+// TryOpen is os.Open tried through tr, just like
+// TryRemoveAll. If it fails, or tr already holds
+// an error, the returned *os.File is nil
+func TryOpen(tr *trier.Trier, name string, opts ...trier.TryOption) *os.File {
+	return TryOpenFile(tr, name, os.O_RDONLY, 0, opts...)
+}
+
+// TryOpenFile is os.OpenFile tried through
+// tr, just like TryOpen
+func TryOpenFile(tr *trier.Trier, name string, flag int, perm os.FileMode, opts ...trier.TryOption) *os.File {
+	var f *os.File
+
+	tr.TryWith(trier.Capture(&f, func() (*os.File, error) {
+		return os.OpenFile(name, flag, perm)
+	}), options(opts)...)
+
+	return f
+}
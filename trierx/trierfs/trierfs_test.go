@@ -0,0 +1,109 @@
+package trierfs
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+func TestIsTransient(t *testing.T) {
+	if len(transientErrnos) == 0 {
+		t.Skip("no transient errors on this platform")
+	}
+
+	// Arrange
+	pathErr := &fs.PathError{Op: "rename", Path: "x", Err: transientErrnos[0]}
+
+	// Assert
+	assert.True(t, IsTransient(pathErr))
+	assert.False(t, IsTransient(fs.ErrNotExist))
+	assert.False(t, IsTransient(errors.New("permanent")))
+	assert.False(t, IsTransient(nil))
+}
+
+func TestTryRemoveAll(t *testing.T) {
+	// Arrange
+	dir := filepath.Join(t.TempDir(), "dir")
+	_ = os.MkdirAll(filepath.Join(dir, "nested"), 0o755)
+
+	tr := trier.NewTrier()
+
+	// Act
+	TryRemoveAll(tr, dir)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	_, err := os.Stat(dir)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestTryRename(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	oldpath := filepath.Join(dir, "old")
+	newpath := filepath.Join(dir, "new")
+	_ = os.WriteFile(oldpath, []byte("hello"), 0o644)
+
+	tr := trier.NewTrier()
+
+	// Act
+	TryRename(tr, oldpath, newpath)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	b, _ := os.ReadFile(newpath)
+	assert.Equal(t, "hello", string(b))
+}
+
+func TestTryRenameMissing(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+
+	tr := trier.NewTrier()
+
+	calls := 0
+
+	// Act
+	TryRename(tr, filepath.Join(dir, "missing"), filepath.Join(dir, "new"), trier.WithRetry(3), trier.WithOnRetry(func(attempt int, err error) {
+		calls++
+	}))
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), fs.ErrNotExist)
+	assert.Equal(t, 0, calls)
+}
+
+func TestTryOpen(t *testing.T) {
+	// Arrange
+	name := filepath.Join(t.TempDir(), "file")
+	_ = os.WriteFile(name, []byte("hello"), 0o644)
+
+	tr := trier.NewTrier()
+
+	// Act
+	f := TryOpen(tr, name)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	defer f.Close()
+	b, _ := io.ReadAll(f)
+	assert.Equal(t, "hello", string(b))
+}
+
+func TestTryOpenMissing(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	// Act
+	f := TryOpen(tr, filepath.Join(t.TempDir(), "missing"))
+
+	// Assert
+	assert.Nil(t, f)
+	assert.ErrorIs(t, tr.Err(), fs.ErrNotExist)
+}
@@ -0,0 +1,22 @@
+module github.com/syke99/trier/trierx/triergrpc
+
+go 1.23.0
+
+require (
+	github.com/stretchr/testify v1.8.4
+	github.com/syke99/trier v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.75.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.43.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+	golang.org/x/text v0.28.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/syke99/trier => ../..
@@ -0,0 +1,133 @@
+// Package triergrpc provides a gRPC client
+// interceptor that runs every unary RPC
+// through trier's retry engine. It lives in
+// its own module so that the trier module
+// doesn't depend on gRPC
+package triergrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/syke99/trier"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultPolicy is used by an interceptor
+// whose Policy doesn't set Attempts
+var DefaultPolicy = trier.RetryPolicy{
+	Attempts: 3,
+	Backoff:  trier.ExponentialBackoff(100*time.Millisecond, 5*time.Second),
+	Jitter:   0.2,
+}
+
+// DefaultRetryCodes are the status codes
+// retried when Config doesn't set RetryCodes
+var DefaultRetryCodes = []codes.Code{
+	codes.Unavailable,
+	codes.ResourceExhausted,
+}
+
+// Config configures UnaryClientInterceptor
+type Config struct {
+	// Policy controls retries. If Attempts
+	// isn't set, DefaultPolicy is used. If
+	// RetryIf is set, it replaces RetryCodes
+	Policy trier.RetryPolicy
+	// RetryCodes are the status codes worth
+	// retrying. If nil, DefaultRetryCodes
+	// is used
+	RetryCodes []codes.Code
+	// PerAttemptTimeout, if set, bounds each
+	// attempt separately from the RPC's own
+	// deadline. An attempt that runs out of
+	// time is retried as long as the RPC's
+	// own context isn't done
+	PerAttemptTimeout time.Duration
+	// OnRetry, if set, is called every time
+	// an attempt is about to be retried
+	OnRetry func(ctx context.Context, method string, attempt int, err error)
+}
+
+// retryable reports whether err
+// is worth another attempt
+func (c Config) retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	code := status.Code(err)
+
+	if c.PerAttemptTimeout > 0 && code == codes.DeadlineExceeded {
+		return true
+	}
+
+	retryCodes := c.RetryCodes
+	if retryCodes == nil {
+		retryCodes = DefaultRetryCodes
+	}
+
+	for _, rc := range retryCodes {
+		if code == rc {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnaryClientInterceptor returns an interceptor
+// that tries every unary RPC with cfg's policy.
+// The error returned for a failed RPC is the one
+// its last attempt failed with, so callers can
+// keep inspecting it with the status package
+func UnaryClientInterceptor(cfg Config) grpc.UnaryClientInterceptor {
+	policy := cfg.Policy
+	if policy.Attempts == 0 {
+		policy = DefaultPolicy
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var last error
+
+		p := policy
+
+		if p.RetryIf == nil {
+			p.RetryIf = func(err error) bool {
+				return cfg.retryable(ctx, err)
+			}
+		}
+
+		if cfg.OnRetry != nil {
+			p.OnRetry = func(attempt int, err error) {
+				cfg.OnRetry(ctx, method, attempt, err)
+			}
+		}
+
+		tr := trier.NewTrier(trier.WithName(method)).TryWith(func(args ...any) error {
+			attemptCtx := ctx
+
+			if cfg.PerAttemptTimeout > 0 {
+				var cancel context.CancelFunc
+				attemptCtx, cancel = context.WithTimeout(ctx, cfg.PerAttemptTimeout)
+				defer cancel()
+			}
+
+			last = invoker(attemptCtx, method, req, reply, cc, opts...)
+
+			return last
+		}, trier.WithPolicy(p), trier.WithContext(ctx))
+
+		if tr.Err() == nil {
+			return nil
+		}
+
+		if last == nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+
+		return last
+	}
+}
@@ -0,0 +1,145 @@
+package triergrpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var fastPolicy = trier.RetryPolicy{
+	Attempts: 3,
+	Backoff: func(i int) time.Duration {
+		return time.Millisecond
+	},
+}
+
+// flakyHealth fails its first failures
+// checks with code, then succeeds
+type flakyHealth struct {
+	healthpb.UnimplementedHealthServer
+	calls    atomic.Int32
+	failures int32
+	code     codes.Code
+	delay    time.Duration
+}
+
+func (h *flakyHealth) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if h.calls.Add(1) <= h.failures {
+		if h.delay > 0 {
+			select {
+			case <-time.After(h.delay):
+			case <-ctx.Done():
+			}
+		}
+		return nil, status.Error(h.code, "flaky")
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func dial(t *testing.T, h *flakyHealth, cfg Config) healthpb.HealthClient {
+	lis := bufconn.Listen(1 << 20)
+
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, h)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(cfg)),
+	)
+	assert.Nil(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		srv.Stop()
+	})
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	// Arrange
+	h := &flakyHealth{failures: 2, code: codes.Unavailable}
+
+	var retried []int
+
+	client := dial(t, h, Config{
+		Policy: fastPolicy,
+		OnRetry: func(ctx context.Context, method string, attempt int, err error) {
+			assert.Equal(t, "/grpc.health.v1.Health/Check", method)
+			retried = append(retried, attempt)
+		},
+	})
+
+	// Act
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	assert.Equal(t, []int{1, 2}, retried)
+}
+
+func TestUnaryClientInterceptorExhausted(t *testing.T) {
+	// Arrange
+	h := &flakyHealth{failures: 5, code: codes.ResourceExhausted}
+
+	client := dial(t, h, Config{Policy: fastPolicy})
+
+	// Act
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	// Assert
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, int32(3), h.calls.Load())
+}
+
+func TestUnaryClientInterceptorNotRetryable(t *testing.T) {
+	// Arrange
+	h := &flakyHealth{failures: 5, code: codes.InvalidArgument}
+
+	client := dial(t, h, Config{Policy: fastPolicy})
+
+	// Act
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	// Assert
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, int32(1), h.calls.Load())
+}
+
+func TestUnaryClientInterceptorPerAttemptTimeout(t *testing.T) {
+	// Arrange
+	h := &flakyHealth{failures: 1, code: codes.Unavailable, delay: time.Second}
+
+	client := dial(t, h, Config{
+		Policy:            fastPolicy,
+		PerAttemptTimeout: 20 * time.Millisecond,
+	})
+
+	// Act
+	start := time.Now()
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	assert.Less(t, time.Since(start), time.Second)
+}
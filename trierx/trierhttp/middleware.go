@@ -0,0 +1,110 @@
+package trierhttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/syke99/trier"
+)
+
+// ErrorHandler turns a Trier into a request
+// error-handling framework. Its Middleware
+// creates a Trier for every request, exposes it
+// to handlers with trier.FromContext, recovers
+// any panic into it and, if it holds an error
+// once the handler returns, responds with the
+// status code that error maps to and a JSON body.
+// A panic recovered after the handler started
+// responding aborts the response instead, with
+// http.ErrAbortHandler, so the client doesn't
+// take a truncated body for a complete one
+type ErrorHandler struct {
+	// Codes map errors to status codes. Errors
+	// matching none of them are reported as
+	// 500 Internal Server Error
+	Codes []trier.CodeMapping
+	// Options configure every request's Trier
+	Options []trier.Option
+}
+
+// ErrorBody is the JSON body written for a
+// request whose Trier holds an error. Message
+// is only set for 4xx responses, so internal
+// errors aren't leaked to clients
+type ErrorBody struct {
+	Status  int    `json:"status"`
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+}
+
+// Middleware wraps next with h
+func (h ErrorHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr := trier.NewTrier(h.Options...)
+
+		rw := &responseWriter{ResponseWriter: w}
+
+		panicked := false
+
+		func() {
+			defer func() {
+				if rec := recover(); rec != nil {
+					if rec == http.ErrAbortHandler {
+						panic(rec)
+					}
+					panicked = true
+					tr.Record(&trier.PanicError{Value: rec})
+				}
+			}()
+
+			next.ServeHTTP(rw, r.WithContext(trier.NewContext(r.Context(), tr)))
+		}()
+
+		if panicked && rw.wroteHeader {
+			panic(http.ErrAbortHandler)
+		}
+
+		err := tr.Err()
+		if err == nil || rw.wroteHeader {
+			return
+		}
+
+		status := tr.MapCode(http.StatusInternalServerError, h.Codes...)
+
+		body := ErrorBody{
+			Status: status,
+			Error:  http.StatusText(status),
+		}
+
+		if status < http.StatusInternalServerError {
+			body.Message = err.Error()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
+
+// responseWriter tracks whether the
+// handler already started a response
+type responseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach
+// the underlying http.ResponseWriter
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,114 @@
+package trierhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+var errNotFound = errors.New("user not found")
+
+var errorHandler = ErrorHandler{
+	Codes: []trier.CodeMapping{
+		{Err: errNotFound, Code: http.StatusNotFound},
+	},
+}
+
+func serve(h http.HandlerFunc) (*httptest.ResponseRecorder, ErrorBody) {
+	rec := httptest.NewRecorder()
+
+	errorHandler.Middleware(h).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var body ErrorBody
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+
+	return rec, body
+}
+
+func TestErrorHandlerMiddleware(t *testing.T) {
+	// Act
+	rec, _ := serve(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := trier.FromContext(r.Context())
+		assert.True(t, ok)
+
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ok", rec.Body.String())
+}
+
+func TestErrorHandlerMiddlewareMapsError(t *testing.T) {
+	// Act
+	rec, body := serve(func(w http.ResponseWriter, r *http.Request) {
+		tr, _ := trier.FromContext(r.Context())
+		tr.Record(errNotFound)
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, ErrorBody{Status: 404, Error: "Not Found", Message: "user not found"}, body)
+}
+
+func TestErrorHandlerMiddlewareUnmappedError(t *testing.T) {
+	// Act
+	rec, body := serve(func(w http.ResponseWriter, r *http.Request) {
+		tr, _ := trier.FromContext(r.Context())
+		tr.Record(errors.New("db password is hunter2"))
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, ErrorBody{Status: 500, Error: "Internal Server Error"}, body)
+}
+
+func TestErrorHandlerMiddlewareRecoversPanic(t *testing.T) {
+	// Act
+	rec, body := serve(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, 500, body.Status)
+}
+
+func TestErrorHandlerMiddlewareAlreadyResponded(t *testing.T) {
+	// Act
+	rec, _ := serve(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+
+		tr, _ := trier.FromContext(r.Context())
+		tr.Record(errNotFound)
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestErrorHandlerMiddlewarePanicAfterResponding(t *testing.T) {
+	// Arrange
+	h := errorHandler.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+
+	// Act
+	serveHTTP := func() {
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	// Assert
+	assert.PanicsWithValue(t, http.ErrAbortHandler, serveHTTP)
+	assert.Equal(t, "partial", rec.Body.String())
+}
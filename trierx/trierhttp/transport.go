@@ -0,0 +1,227 @@
+// Package trierhttp provides net/http integrations
+// built on top of trier's retry engine
+package trierhttp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/syke99/trier"
+)
+
+// DefaultPolicy is used by a Transport
+// whose Policy doesn't set Attempts
+var DefaultPolicy = trier.RetryPolicy{
+	Attempts: 3,
+	Backoff:  trier.ExponentialBackoff(100*time.Millisecond, 5*time.Second),
+	Jitter:   0.2,
+}
+
+// StatusError is returned by an attempt
+// whose response had a retryable status
+type StatusError struct {
+	Response *http.Response
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("retryable response status %s", e.Response.Status)
+}
+
+// StatusCode returns the response's status code,
+// so trier.HTTPStatusClassifier can classify it
+func (e *StatusError) StatusCode() int {
+	return e.Response.StatusCode
+}
+
+// RetryAfter returns how long the response's
+// Retry-After header asked to wait, if it
+// had one, so the retry engine honors it
+// in place of the Transport's backoff
+func (e *StatusError) RetryAfter() time.Duration {
+	return parseRetryAfter(e.Response.Header.Get("Retry-After"), time.Now())
+}
+
+// Transport is an http.RoundTripper that
+// wraps another RoundTripper, retrying
+// requests that fail with a transport error
+// or a retryable status code. Swapping it in
+// as an http.Client's Transport makes that
+// client resilient without changing any
+// code that uses it
+type Transport struct {
+	// Base makes each attempt. If nil,
+	// http.DefaultTransport is used
+	Base http.RoundTripper
+	// Policy controls retries. If Attempts
+	// isn't set, DefaultPolicy is used
+	Policy trier.RetryPolicy
+	// RetryStatus reports whether a status
+	// code should be retried. If nil,
+	// DefaultRetryStatus is used
+	RetryStatus func(code int) bool
+	// RetryNonIdempotent allows retrying
+	// requests other than GET, HEAD, OPTIONS,
+	// TRACE, PUT and DELETE that don't carry
+	// an Idempotency-Key header
+	RetryNonIdempotent bool
+	// IdempotencyKeys sets an Idempotency-Key
+	// header on non-idempotent requests that
+	// don't have one, so they can be retried.
+	// The key is that of the step the request's
+	// context was passed to by trier.TryCtx, if
+	// any, so it's also reused when the whole
+	// step is retried, or a new one otherwise
+	IdempotencyKeys bool
+}
+
+// DefaultRetryStatus retries 429 Too Many
+// Requests along with 502, 503 and 504
+func DefaultRetryStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// RoundTrip implements http.RoundTripper. If
+// every attempt got a retryable status, the
+// last response is returned as is, so callers
+// see it just like they would without retries
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	retryStatus := t.RetryStatus
+	if retryStatus == nil {
+		retryStatus = DefaultRetryStatus
+	}
+
+	policy := t.Policy
+	if policy.Attempts == 0 {
+		policy = DefaultPolicy
+	}
+
+	if t.IdempotencyKeys && !idempotent(req.Method) && req.Header.Get("Idempotency-Key") == "" {
+		key := trier.IdempotencyKey(req.Context())
+		if key == "" {
+			key = trier.NewIdempotencyKey()
+		}
+
+		req = req.Clone(req.Context())
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	if !t.retryable(req) {
+		policy.Attempts = 1
+	}
+
+	var (
+		resp    *http.Response
+		attempt int
+	)
+
+	tr := trier.NewTrier().TryWith(func(args ...any) error {
+		r := req
+
+		if resp != nil {
+			discard(resp)
+			resp = nil
+		}
+
+		// every attempt after the first needs a
+		// fresh body, whether the last one got a
+		// response or failed partway through
+		// sending the body it was given
+		attempt++
+		if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+
+		res, err := base.RoundTrip(r)
+		if err != nil {
+			return err
+		}
+
+		resp = res
+
+		if retryStatus(res.StatusCode) {
+			return &StatusError{Response: res}
+		}
+
+		return nil
+	}, trier.WithPolicy(policy), trier.WithContext(req.Context()))
+
+	if resp != nil {
+		return resp, nil
+	}
+
+	return nil, tr.Err()
+}
+
+// retryable reports whether req can
+// safely be sent more than once
+func (t *Transport) retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	return t.RetryNonIdempotent ||
+		req.Header.Get("Idempotency-Key") != "" ||
+		idempotent(req.Method)
+}
+
+// idempotent reports whether requests
+// made with method can be sent repeatedly
+// with the same effect as sending them once
+func idempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// discard drains and closes the body of a
+// response that is being retried, so the
+// underlying connection can be reused
+func discard(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+	_ = resp.Body.Close()
+}
+
+// parseRetryAfter parses a Retry-After
+// header given either in seconds or as
+// an HTTP date
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second
+	}
+
+	if at, err := http.ParseTime(v); err == nil {
+		return max(at.Sub(now), 0)
+	}
+
+	return 0
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
@@ -0,0 +1,288 @@
+package trierhttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+var fastPolicy = trier.RetryPolicy{
+	Attempts: 3,
+	Backoff: func(i int) time.Duration {
+		return time.Millisecond
+	},
+}
+
+func flakyServer(failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+
+		_, _ = w.Write(append([]byte("ok"), body...))
+	}))
+
+	return srv, &calls
+}
+
+func TestTransportRoundTrip(t *testing.T) {
+	// Arrange
+	srv, calls := flakyServer(2, http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{Policy: fastPolicy}}
+
+	// Act
+	resp, err := client.Get(srv.URL)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestTransportRoundTripExhausted(t *testing.T) {
+	// Arrange
+	srv, calls := flakyServer(5, http.StatusBadGateway)
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{Policy: fastPolicy}}
+
+	// Act
+	resp, err := client.Get(srv.URL)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestTransportRoundTripNotRetryableStatus(t *testing.T) {
+	// Arrange
+	srv, calls := flakyServer(1, http.StatusNotFound)
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{Policy: fastPolicy}}
+
+	// Act
+	resp, err := client.Get(srv.URL)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestTransportRoundTripRewindsBody(t *testing.T) {
+	// Arrange
+	srv, _ := flakyServer(1, http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{Policy: fastPolicy}}
+
+	req, _ := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader(" body"))
+
+	// Act
+	resp, err := client.Do(req)
+
+	// Assert
+	assert.Nil(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "ok body", string(body))
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransportRoundTripRewindsBodyAfterError(t *testing.T) {
+	// Arrange
+	var bodies []string
+
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) == 1 {
+			return nil, errors.New("connection reset")
+		}
+
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	client := &http.Client{Transport: &Transport{Base: base, Policy: fastPolicy}}
+
+	req, _ := http.NewRequest(http.MethodPut, "http://example.com", strings.NewReader("payload"))
+
+	// Act
+	resp, err := client.Do(req)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"payload", "payload"}, bodies)
+}
+
+func TestTransportRoundTripNonIdempotent(t *testing.T) {
+	// Arrange
+	srv, calls := flakyServer(1, http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{Policy: fastPolicy}}
+
+	req, _ := http.NewRequest(http.MethodPatch, srv.URL, strings.NewReader("body"))
+
+	// Act
+	resp, err := client.Do(req)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+// keyServer fails the first request it gets,
+// recording the Idempotency-Key of each
+func keyServer() (*httptest.Server, func() []string) {
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return keys
+	}
+}
+
+func TestTransportRoundTripIdempotencyKeys(t *testing.T) {
+	// Arrange
+	srv, keys := keyServer()
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{Policy: fastPolicy, IdempotencyKeys: true}}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("body"))
+
+	// Act
+	resp, err := client.Do(req)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, keys(), 2)
+	assert.NotEmpty(t, keys()[0])
+	assert.Equal(t, keys()[0], keys()[1])
+	assert.Empty(t, req.Header.Get("Idempotency-Key"))
+}
+
+func TestTransportRoundTripIdempotencyKeysFromStep(t *testing.T) {
+	// Arrange
+	srv, keys := keyServer()
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{Policy: fastPolicy, IdempotencyKeys: true}}
+
+	var stepKey string
+
+	tr := trier.NewTrier()
+
+	// Act
+	tr.TryCtx(context.Background(), func(ctx context.Context) error {
+		stepKey = trier.IdempotencyKey(ctx)
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL, nil)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	})
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, []string{stepKey, stepKey}, keys())
+}
+
+func TestTransportRoundTripRetryAfter(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{Policy: fastPolicy}}
+
+	// Act
+	start := time.Now()
+	resp, err := client.Get(srv.URL)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestTransportRoundTripError(t *testing.T) {
+	// Arrange
+	srv, _ := flakyServer(0, http.StatusOK)
+	srv.Close()
+
+	client := &http.Client{Transport: &Transport{Policy: fastPolicy}}
+
+	// Act
+	_, err := client.Get(srv.URL)
+
+	// Assert
+	assert.NotNil(t, err)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	secs := parseRetryAfter("3", now)
+	date := parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now)
+	bad := parseRetryAfter("soon", now)
+
+	// Assert
+	assert.Equal(t, 3*time.Second, secs)
+	assert.Equal(t, time.Minute, date)
+	assert.Equal(t, time.Duration(0), bad)
+}
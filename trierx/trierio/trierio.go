@@ -0,0 +1,219 @@
+// Package trierio provides io.Reader and io.Writer
+// wrappers that retry transient failures with
+// trier's retry engine, recording any error they
+// give up on into a Trier
+package trierio
+
+import (
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/syke99/trier"
+)
+
+// DefaultPolicy is used when no
+// TryOptions are given
+var DefaultPolicy = trier.RetryPolicy{
+	Attempts: 5,
+	Backoff:  trier.ExponentialBackoff(10*time.Millisecond, time.Second),
+	Jitter:   0.2,
+}
+
+// IsTransient reports whether err is a read or
+// write failure likely to succeed if retried,
+// such as EAGAIN, EINTR, a connection reset or
+// a network timeout
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, io.EOF) {
+		return false
+	}
+
+	if errors.Is(err, syscall.EAGAIN) ||
+		errors.Is(err, syscall.EINTR) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ETIMEDOUT) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// options applies DefaultPolicy if opts is
+// empty, and IsTransient as the default
+// retry predicate, so any given WithRetryIf
+// replaces it
+func options(opts []trier.TryOption) []trier.TryOption {
+	if len(opts) == 0 {
+		opts = []trier.TryOption{trier.WithPolicy(DefaultPolicy)}
+	}
+
+	return append([]trier.TryOption{trier.WithRetryIf(IsTransient)}, opts...)
+}
+
+// Reader wraps an io.Reader, retrying
+// Reads that fail transiently
+type Reader struct {
+	r    io.Reader
+	s    io.Seeker
+	tr   *trier.Trier
+	opts []trier.TryOption
+	off  int64
+}
+
+// NewReader wraps r so that every Read is tried
+// through tr with opts (such as WithRetry and
+// WithBackoff), or DefaultPolicy if no opts
+// are given. By default only errors for which
+// IsTransient returns true are retried, which
+// WithRetryIf can override. If r is also an
+// io.Seeker, it is seeked back to where the
+// failed Read started before each retry. Once
+// tr holds an error, every Read fails with it
+func NewReader(tr *trier.Trier, r io.Reader, opts ...trier.TryOption) *Reader {
+	rd := &Reader{r: r, tr: tr, opts: options(opts)}
+
+	if s, ok := r.(io.Seeker); ok {
+		if off, err := s.Seek(0, io.SeekCurrent); err == nil {
+			rd.s = s
+			rd.off = off
+		}
+	}
+
+	return rd
+}
+
+// Read implements io.Reader. If a Read returns
+// data along with a transient error, the data
+// is returned without the error, leaving the
+// next Read to hit it again
+func (r *Reader) Read(p []byte) (int, error) {
+	if err := r.tr.Err(); err != nil {
+		return 0, err
+	}
+
+	var (
+		n        int
+		last     error
+		attempts int
+	)
+
+	r.tr.TryWith(func(args ...any) error {
+		if attempts > 0 && r.s != nil {
+			if _, err := r.s.Seek(r.off, io.SeekStart); err != nil {
+				last = err
+				return err
+			}
+		}
+
+		attempts++
+
+		n, last = r.r.Read(p)
+		if n > 0 || last == nil || errors.Is(last, io.EOF) {
+			return nil
+		}
+
+		return last
+	}, r.opts...)
+
+	r.off += int64(n)
+
+	if n > 0 && IsTransient(last) {
+		last = nil
+	}
+
+	// tr skipped the Read altogether (such as
+	// once its chain timeout has passed)
+	if attempts == 0 {
+		return 0, skipped(r.tr)
+	}
+
+	return n, last
+}
+
+// Writer wraps an io.Writer, retrying
+// Writes that fail transiently
+type Writer struct {
+	w    io.Writer
+	s    io.Seeker
+	tr   *trier.Trier
+	opts []trier.TryOption
+	off  int64
+}
+
+// NewWriter wraps w so that every Write is tried
+// through tr with opts, just like NewReader. A
+// retry only writes whatever part of p hasn't
+// been written yet, after seeking w to where
+// that part should go if w is an io.Seeker
+func NewWriter(tr *trier.Trier, w io.Writer, opts ...trier.TryOption) *Writer {
+	wr := &Writer{w: w, tr: tr, opts: options(opts)}
+
+	if s, ok := w.(io.Seeker); ok {
+		if off, err := s.Seek(0, io.SeekCurrent); err == nil {
+			wr.s = s
+			wr.off = off
+		}
+	}
+
+	return wr
+}
+
+// Write implements io.Writer
+func (w *Writer) Write(p []byte) (int, error) {
+	if err := w.tr.Err(); err != nil {
+		return 0, err
+	}
+
+	var (
+		written  int
+		last     error
+		attempts int
+	)
+
+	w.tr.TryWith(func(args ...any) error {
+		if attempts > 0 && w.s != nil {
+			if _, err := w.s.Seek(w.off+int64(written), io.SeekStart); err != nil {
+				last = err
+				return err
+			}
+		}
+
+		attempts++
+
+		n, err := w.w.Write(p[written:])
+		written += n
+
+		if err == nil && written < len(p) {
+			err = io.ErrShortWrite
+		}
+
+		last = err
+
+		return err
+	}, w.opts...)
+
+	w.off += int64(written)
+
+	// tr skipped the Write altogether (such
+	// as once its chain timeout has passed),
+	// and io.Writer requires an error for
+	// anything short of all of p
+	if last == nil && written < len(p) {
+		last = skipped(w.tr)
+	}
+
+	return written, last
+}
+
+// skipped returns the error to report for a
+// Read or Write that tr didn't try at all
+func skipped(tr *trier.Trier) error {
+	if err := tr.Err(); err != nil {
+		return err
+	}
+	return io.ErrNoProgress
+}
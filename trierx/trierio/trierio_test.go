@@ -0,0 +1,220 @@
+package trierio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+// flakyReader fails with err every
+// other Read, starting with the first
+type flakyReader struct {
+	r     io.Reader
+	err   error
+	calls int
+}
+
+func (f *flakyReader) Read(p []byte) (int, error) {
+	f.calls++
+	if f.calls%2 == 1 {
+		return 0, f.err
+	}
+	return f.r.Read(p[:min(len(p), 4)])
+}
+
+// flakyWriter writes at most 3 bytes
+// before failing with err every
+// other Write, starting with the first
+type flakyWriter struct {
+	bytes.Buffer
+	err   error
+	calls int
+}
+
+func (f *flakyWriter) Write(p []byte) (int, error) {
+	f.calls++
+	if f.calls%2 == 1 {
+		n, _ := f.Buffer.Write(p[:min(len(p), 3)])
+		return n, f.err
+	}
+	return f.Buffer.Write(p)
+}
+
+// seekingReader fails after
+// advancing past what it read
+type seekingReader struct {
+	*strings.Reader
+	failed bool
+}
+
+func (s *seekingReader) Read(p []byte) (int, error) {
+	if !s.failed {
+		s.failed = true
+		_, _ = s.Reader.Read(make([]byte, 3))
+		return 0, syscall.EAGAIN
+	}
+	return s.Reader.Read(p)
+}
+
+func TestIsTransient(t *testing.T) {
+	// Assert
+	assert.True(t, IsTransient(syscall.EAGAIN))
+	assert.True(t, IsTransient(syscall.ECONNRESET))
+	assert.False(t, IsTransient(io.EOF))
+	assert.False(t, IsTransient(errors.New("permanent")))
+	assert.False(t, IsTransient(nil))
+}
+
+func TestReader(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	r := NewReader(tr, &flakyReader{r: strings.NewReader("hello world"), err: syscall.EAGAIN}, trier.WithRetry(2))
+
+	// Act
+	b, err := io.ReadAll(r)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, "hello world", string(b))
+}
+
+func TestReaderPermanentError(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	errPermanent := errors.New("permanent")
+
+	f := &flakyReader{r: strings.NewReader("hello"), err: errPermanent}
+
+	r := NewReader(tr, f, trier.WithRetry(3))
+
+	// Act
+	_, err := io.ReadAll(r)
+
+	// Assert
+	assert.Equal(t, errPermanent, err)
+	assert.Equal(t, errPermanent, tr.Err())
+	assert.Equal(t, 1, f.calls)
+}
+
+func TestReaderExhausted(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	r := NewReader(tr, &flakyReader{r: strings.NewReader("hello"), err: syscall.EAGAIN}, trier.WithRetry(1))
+
+	// Act
+	_, err := io.ReadAll(r)
+	_, again := r.Read(make([]byte, 1))
+
+	// Assert
+	assert.ErrorIs(t, err, syscall.EAGAIN)
+	assert.ErrorIs(t, tr.Err(), syscall.EAGAIN)
+	assert.ErrorIs(t, again, syscall.EAGAIN)
+}
+
+func TestReaderSeeksBack(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	r := NewReader(tr, &seekingReader{Reader: strings.NewReader("hello")}, trier.WithRetry(2))
+
+	// Act
+	b, err := io.ReadAll(r)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(b))
+}
+
+func TestWriter(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	f := &flakyWriter{err: syscall.EINTR}
+
+	w := NewWriter(tr, f, trier.WithRetry(2))
+
+	// Act
+	n, err := w.Write([]byte("hello world"))
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, 11, n)
+	assert.Equal(t, "hello world", f.String())
+}
+
+func TestWriterExhausted(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	f := &flakyWriter{err: syscall.EINTR}
+
+	w := NewWriter(tr, f, trier.WithRetry(1))
+
+	// Act
+	n, err := w.Write([]byte("hello world"))
+
+	// Assert
+	assert.ErrorIs(t, err, syscall.EINTR)
+	assert.ErrorIs(t, tr.Err(), syscall.EINTR)
+	assert.Equal(t, 3, n)
+}
+
+func TestWriterDefaultPolicy(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	f := &flakyWriter{err: syscall.EINTR}
+
+	w := NewWriter(tr, f)
+
+	// Act
+	n, err := w.Write([]byte("hello world"))
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 11, n)
+	assert.Equal(t, "hello world", f.String())
+}
+
+func TestWriterSkipped(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier(trier.WithChainTimeout(-time.Second))
+
+	var buf bytes.Buffer
+
+	w := NewWriter(tr, &buf)
+
+	// Act
+	n, err := w.Write([]byte("hello"))
+
+	// Assert
+	assert.Equal(t, 0, n)
+	assert.ErrorIs(t, err, trier.ErrChainTimeout)
+	assert.Empty(t, buf.String())
+}
+
+func TestReaderSkipped(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier(trier.WithChainTimeout(-time.Second))
+
+	r := NewReader(tr, strings.NewReader("hello"))
+
+	// Act
+	n, err := r.Read(make([]byte, 5))
+
+	// Assert
+	assert.Equal(t, 0, n)
+	assert.ErrorIs(t, err, trier.ErrChainTimeout)
+}
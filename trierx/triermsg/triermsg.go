@@ -0,0 +1,137 @@
+// Package triermsg provides a broker-agnostic
+// adapter for message consumers (Kafka, SQS,
+// NATS and the like) that retries a message's
+// handler with trier's retry engine and then
+// acknowledges the message based on the outcome
+package triermsg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/syke99/trier"
+)
+
+// Message is implemented by a consumed message,
+// or an adapter around one, to settle it with
+// its broker once it has been processed
+type Message interface {
+	// Ack marks the message as
+	// successfully processed
+	Ack(ctx context.Context) error
+	// Nack returns the message to the broker
+	// so it can be redelivered later
+	Nack(ctx context.Context) error
+	// DeadLetter moves the message aside
+	// (e.g. to a dead-letter queue) since
+	// processing it can never succeed
+	DeadLetter(ctx context.Context, err error) error
+}
+
+// Outcome describes how a message was settled
+type Outcome int
+
+const (
+	// Acked means the handler succeeded
+	Acked Outcome = iota
+	// Nacked means the handler failed
+	// transiently and kept failing until
+	// it ran out of retries, or that it
+	// was never run (or stopped) because
+	// the Trier already held an error or
+	// ctx was done
+	Nacked
+	// DeadLettered means the handler
+	// failed with a permanent error
+	DeadLettered
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Acked:
+		return "acked"
+	case Nacked:
+		return "nacked"
+	case DeadLettered:
+		return "dead-lettered"
+	}
+	return "unknown"
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks err as permanent, so a handler
+// returning it isn't retried and its message is
+// dead-lettered rather than redelivered
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err
+// was marked with Permanent
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// ProcessMessage tries handler with msg through tr
+// with opts (such as WithRetry and WithBackoff),
+// never retrying errors marked with Permanent, and
+// then settles msg: it is acked if handler succeeds,
+// dead-lettered if handler returns a permanent error
+// and nacked otherwise. Any error from handler or
+// from settling msg is recorded into tr
+func ProcessMessage[M Message](ctx context.Context, tr *trier.Trier, msg M, handler func(ctx context.Context, msg M) error, opts ...trier.TryOption) Outcome {
+	var (
+		ran  bool
+		last error
+	)
+
+	opts = append([]trier.TryOption{
+		trier.WithRetryIf(func(err error) bool {
+			return !IsPermanent(err)
+		}),
+		trier.WithContext(ctx),
+	}, opts...)
+
+	tr.TryWith(func(args ...any) error {
+		ran = true
+		last = handler(ctx, msg)
+		return last
+	}, opts...)
+
+	outcome := Nacked
+
+	switch {
+	case ran && last == nil:
+		outcome = Acked
+	case IsPermanent(last):
+		outcome = DeadLettered
+	}
+
+	tr.TryJoinFunc(func() error {
+		switch outcome {
+		case Acked:
+			return msg.Ack(ctx)
+		case DeadLettered:
+			return msg.DeadLetter(ctx, last)
+		default:
+			return msg.Nack(ctx)
+		}
+	})
+
+	return outcome
+}
@@ -0,0 +1,142 @@
+package triermsg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+type fakeMessage struct {
+	body      string
+	settled   []string
+	letterErr error
+	ackErr    error
+}
+
+func (m *fakeMessage) Ack(ctx context.Context) error {
+	m.settled = append(m.settled, "ack")
+	return m.ackErr
+}
+
+func (m *fakeMessage) Nack(ctx context.Context) error {
+	m.settled = append(m.settled, "nack")
+	return nil
+}
+
+func (m *fakeMessage) DeadLetter(ctx context.Context, err error) error {
+	m.settled = append(m.settled, "dead-letter")
+	m.letterErr = err
+	return nil
+}
+
+func TestProcessMessage(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	msg := &fakeMessage{body: "hello"}
+
+	calls := 0
+
+	// Act
+	outcome := ProcessMessage(context.Background(), tr, msg, func(ctx context.Context, msg *fakeMessage) error {
+		calls++
+		if calls < 2 {
+			return errors.New("transient")
+		}
+		return nil
+	}, trier.WithRetry(3))
+
+	// Assert
+	assert.Equal(t, Acked, outcome)
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, []string{"ack"}, msg.settled)
+}
+
+func TestProcessMessageExhausted(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	msg := &fakeMessage{}
+
+	// Act
+	outcome := ProcessMessage(context.Background(), tr, msg, func(ctx context.Context, msg *fakeMessage) error {
+		return errors.New("transient")
+	}, trier.WithRetry(2))
+
+	// Assert
+	assert.Equal(t, Nacked, outcome)
+	assert.Equal(t, "transient (2 times)", tr.Err().Error())
+	assert.Equal(t, []string{"nack"}, msg.settled)
+}
+
+func TestProcessMessagePermanent(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	msg := &fakeMessage{}
+
+	errPoison := errors.New("poison")
+
+	calls := 0
+
+	// Act
+	outcome := ProcessMessage(context.Background(), tr, msg, func(ctx context.Context, msg *fakeMessage) error {
+		calls++
+		return Permanent(errPoison)
+	}, trier.WithRetry(3))
+
+	// Assert
+	assert.Equal(t, DeadLettered, outcome)
+	assert.Equal(t, 1, calls)
+	assert.ErrorIs(t, msg.letterErr, errPoison)
+	assert.ErrorIs(t, tr.Err(), errPoison)
+	assert.Equal(t, []string{"dead-letter"}, msg.settled)
+}
+
+func TestProcessMessagePreviousError(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier().TryFunc(func() error {
+		return errors.New("failed")
+	})
+
+	msg := &fakeMessage{}
+
+	calls := 0
+
+	// Act
+	outcome := ProcessMessage(context.Background(), tr, msg, func(ctx context.Context, msg *fakeMessage) error {
+		calls++
+		return nil
+	})
+
+	// Assert
+	assert.Equal(t, Nacked, outcome)
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, []string{"nack"}, msg.settled)
+}
+
+func TestProcessMessageAckError(t *testing.T) {
+	// Arrange
+	tr := trier.NewTrier()
+
+	msg := &fakeMessage{ackErr: errors.New("ack failed")}
+
+	// Act
+	outcome := ProcessMessage(context.Background(), tr, msg, func(ctx context.Context, msg *fakeMessage) error {
+		return nil
+	})
+
+	// Assert
+	assert.Equal(t, Acked, outcome)
+	assert.Equal(t, "ack failed", tr.Err().Error())
+}
+
+func TestOutcomeString(t *testing.T) {
+	// Assert
+	assert.Equal(t, "acked", Acked.String())
+	assert.Equal(t, "nacked", Nacked.String())
+	assert.Equal(t, "dead-lettered", DeadLettered.String())
+}
@@ -0,0 +1,15 @@
+//go:build !plan9
+
+package triernet
+
+import "syscall"
+
+// transientErrnos are the connection level
+// errors worth retrying a dial for
+var transientErrnos = []error{
+	syscall.ECONNREFUSED,
+	syscall.ECONNRESET,
+	syscall.ECONNABORTED,
+	syscall.EHOSTUNREACH,
+	syscall.ENETUNREACH,
+}
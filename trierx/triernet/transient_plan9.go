@@ -0,0 +1,6 @@
+package triernet
+
+// transientErrnos is empty on plan9, which
+// has no errnos, so only timeouts and
+// temporary DNS failures are retried
+var transientErrnos []error
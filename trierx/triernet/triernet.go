@@ -0,0 +1,180 @@
+// Package triernet provides a net.Dialer wrapper
+// that retries transient dial and DNS failures
+// with trier's retry engine
+package triernet
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/syke99/trier"
+)
+
+// DefaultPolicy is used by a Dialer whose
+// Policy doesn't set Attempts
+var DefaultPolicy = trier.RetryPolicy{
+	Attempts: 4,
+	Backoff:  trier.ExponentialBackoff(50*time.Millisecond, 2*time.Second),
+	Jitter:   0.2,
+	RetryIf:  IsTransient,
+}
+
+// IsTransient reports whether err is a dial
+// or lookup failure that is likely to succeed
+// if retried, such as a refused or reset
+// connection, an unreachable network, a
+// timed out attempt or a temporary DNS
+// failure. Hosts that don't exist and
+// canceled contexts aren't transient
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound && (dnsErr.IsTemporary || dnsErr.IsTimeout)
+	}
+
+	for _, errno := range transientErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Dialer dials connections and looks up
+// hosts, retrying transient failures.
+// Its DialContext can be used anywhere a
+// dial function is expected, such as
+// http.Transport's DialContext
+type Dialer struct {
+	// Dialer does the dialing. If it's nil,
+	// a zero net.Dialer is used, and its
+	// Resolver (or net.DefaultResolver)
+	// is used for lookups
+	Dialer *net.Dialer
+	// Policy controls retries. If Attempts
+	// isn't set, DefaultPolicy is used, and
+	// if RetryIf isn't set, IsTransient is
+	Policy trier.RetryPolicy
+}
+
+func (d *Dialer) dialer() *net.Dialer {
+	if d.Dialer == nil {
+		return &net.Dialer{}
+	}
+	return d.Dialer
+}
+
+func (d *Dialer) resolver() *net.Resolver {
+	if r := d.dialer().Resolver; r != nil {
+		return r
+	}
+	return net.DefaultResolver
+}
+
+func (d *Dialer) options(ctx context.Context) []trier.TryOption {
+	policy := d.Policy
+	if policy.Attempts == 0 {
+		policy = DefaultPolicy
+	}
+
+	if policy.RetryIf == nil {
+		policy.RetryIf = IsTransient
+	}
+
+	return []trier.TryOption{trier.WithPolicy(policy), trier.WithContext(ctx)}
+}
+
+// TryDial dials address on network through tr,
+// retrying transient failures. Like any Try,
+// nothing is done if tr already holds an
+// error, in which case nil is returned
+func (d *Dialer) TryDial(ctx context.Context, tr *trier.Trier, network, address string) net.Conn {
+	var conn net.Conn
+
+	tr.TryWith(trier.Capture(&conn, func() (net.Conn, error) {
+		return d.dialer().DialContext(ctx, network, address)
+	}), d.options(ctx)...)
+
+	return conn
+}
+
+// DialContext dials address on network,
+// retrying transient failures, and returns
+// the last attempt's error if it never
+// succeeds
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	var (
+		conn net.Conn
+		last error
+	)
+
+	tr := trier.NewTrier().TryWith(func(args ...any) error {
+		conn, last = d.dialer().DialContext(ctx, network, address)
+		return last
+	}, d.options(ctx)...)
+
+	return conn, lastErr(tr, last)
+}
+
+// Dial is like DialContext, but
+// with a background context
+func (d *Dialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// TryLookupHost looks up host's addresses
+// through tr, retrying transient failures
+func (d *Dialer) TryLookupHost(ctx context.Context, tr *trier.Trier, host string) []string {
+	var addrs []string
+
+	tr.TryWith(trier.Capture(&addrs, func() ([]string, error) {
+		return d.resolver().LookupHost(ctx, host)
+	}), d.options(ctx)...)
+
+	return addrs
+}
+
+// LookupHost looks up host's addresses,
+// retrying transient failures, and returns
+// the last attempt's error if it never
+// succeeds
+func (d *Dialer) LookupHost(ctx context.Context, host string) ([]string, error) {
+	var (
+		addrs []string
+		last  error
+	)
+
+	tr := trier.NewTrier().TryWith(func(args ...any) error {
+		addrs, last = d.resolver().LookupHost(ctx, host)
+		return last
+	}, d.options(ctx)...)
+
+	return addrs, lastErr(tr, last)
+}
+
+// lastErr returns the error the last attempt
+// failed with, or tr's error if ctx was done
+// before anything was attempted
+func lastErr(tr *trier.Trier, last error) error {
+	if tr.Err() == nil {
+		return nil
+	}
+
+	if last == nil {
+		return tr.Err()
+	}
+
+	return last
+}
@@ -0,0 +1,165 @@
+package triernet
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+var fastPolicy = trier.RetryPolicy{
+	Attempts: 3,
+	Backoff: func(i int) time.Duration {
+		return time.Millisecond
+	},
+}
+
+// closedAddr returns the address of a
+// listener that has since been closed,
+// so dialing it is refused
+func closedAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := l.Addr().String()
+	l.Close()
+
+	return addr
+}
+
+func TestIsTransient(t *testing.T) {
+	// Arrange
+	cases := map[error]bool{
+		nil:                  false,
+		context.Canceled:     false,
+		errors.New("boom"):   false,
+		syscall.ECONNREFUSED: true,
+		&net.OpError{Op: "dial", Err: syscall.ECONNRESET}: true,
+		&net.DNSError{IsNotFound: true}:                   false,
+		&net.DNSError{IsTemporary: true}:                  true,
+		&net.DNSError{IsTimeout: true}:                    true,
+	}
+
+	for err, want := range cases {
+		// Act
+		got := IsTransient(err)
+
+		// Assert
+		assert.Equal(t, want, got, "%v", err)
+	}
+}
+
+func TestDialerDialContext(t *testing.T) {
+	// Arrange
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	d := &Dialer{Policy: fastPolicy}
+
+	// Act
+	conn, err := d.DialContext(context.Background(), "tcp", l.Addr().String())
+
+	// Assert
+	assert.Nil(t, err)
+	assert.NotNil(t, conn)
+	conn.Close()
+}
+
+func TestDialerDialRetries(t *testing.T) {
+	// Arrange
+	addr := closedAddr(t)
+
+	attempts := 0
+
+	policy := fastPolicy
+	policy.OnRetry = func(attempt int, err error) {
+		attempts = attempt
+		if attempt == 2 {
+			l, err := net.Listen("tcp", addr)
+			if err != nil {
+				t.Skip("couldn't reuse address:", err)
+			}
+			t.Cleanup(func() { l.Close() })
+		}
+	}
+
+	d := &Dialer{Policy: policy}
+
+	// Act
+	conn, err := d.Dial("tcp", addr)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+	conn.Close()
+}
+
+func TestDialerDialExhausted(t *testing.T) {
+	// Arrange
+	addr := closedAddr(t)
+
+	d := &Dialer{Policy: fastPolicy}
+
+	// Act
+	conn, err := d.Dial("tcp", addr)
+
+	// Assert
+	assert.Nil(t, conn)
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+
+	var opErr *net.OpError
+	assert.True(t, errors.As(err, &opErr))
+}
+
+func TestDialerTryDial(t *testing.T) {
+	// Arrange
+	addr := closedAddr(t)
+
+	tr := trier.NewTrier()
+
+	d := &Dialer{Policy: fastPolicy}
+
+	// Act
+	conn := d.TryDial(context.Background(), tr, "tcp", addr)
+
+	// Assert
+	assert.Nil(t, conn)
+	assert.ErrorIs(t, tr.Err(), syscall.ECONNREFUSED)
+}
+
+func TestDialerDialCanceled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	d := &Dialer{Policy: fastPolicy}
+
+	// Act
+	conn, err := d.DialContext(ctx, "tcp", closedAddr(t))
+
+	// Assert
+	assert.Nil(t, conn)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDialerLookupHost(t *testing.T) {
+	// Arrange
+	d := &Dialer{Policy: fastPolicy}
+
+	// Act
+	addrs, err := d.LookupHost(context.Background(), "127.0.0.1")
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"127.0.0.1"}, addrs)
+}
@@ -0,0 +1,314 @@
+// Package trierqueue persists failed steps to a
+// Queue so they can be retried by a Worker later,
+// even by another process or after a restart,
+// for failures that outlast in-memory retries
+package trierqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/syke99/trier"
+)
+
+// ErrEmpty is returned by a Queue's Dequeue
+// when no Job is due to be attempted yet
+var ErrEmpty = errors.New("trierqueue: no job is due")
+
+// Job is a failed step waiting to be retried
+type Job struct {
+	// Step is the name of the Handler
+	// registered with the Worker to run it
+	Step string
+	// Payload is passed to the Handler,
+	// and should hold everything it needs
+	Payload []byte
+	// NextAttempt is the earliest time
+	// the Job should be dequeued
+	NextAttempt time.Time
+	// Attempts is how many times the Job has
+	// been tried by a Worker so far
+	Attempts int
+	// LastError is the message of the error
+	// the Job's latest attempt failed with
+	LastError string
+}
+
+// Queue stores Jobs durably, such as in a
+// database table or a message broker
+type Queue interface {
+	// Enqueue stores job until its NextAttempt
+	Enqueue(ctx context.Context, job Job) error
+	// Dequeue removes and returns a Job whose
+	// NextAttempt has passed, or ErrEmpty
+	// if there isn't one
+	Dequeue(ctx context.Context) (Job, error)
+}
+
+// Handler runs a step from its payload
+type Handler func(ctx context.Context, payload []byte) error
+
+// Worker pulls Jobs from a Queue and runs
+// them through a Trier with Policy, putting
+// them back on the Queue to be tried again
+// later if they still fail
+type Worker struct {
+	// Queue is where Jobs are pulled
+	// from and put back on
+	Queue Queue
+	// Policy controls the retries made each
+	// time a Job is pulled. A Job that fails
+	// with an error Policy.RetryIf rejects
+	// isn't put back on the Queue
+	Policy trier.RetryPolicy
+	// MaxAttempts is how many times a Job is
+	// pulled before it's given up on.
+	// Zero means it never is
+	MaxAttempts int
+	// Delay returns how long to wait before
+	// pulling a Job again after its attempt'th
+	// failure (starting at zero). It defaults
+	// to exponential backoff from a minute
+	// up to an hour
+	Delay func(attempt int) time.Duration
+	// PollInterval is how long Run waits when
+	// the Queue is empty. It defaults to a second
+	PollInterval time.Duration
+	// OnDrop, if set, is called with any Job
+	// that is given up on and the error it
+	// last failed with
+	OnDrop func(job Job, err error)
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewWorker returns a Worker pulling from q
+func NewWorker(q Queue) *Worker {
+	return &Worker{
+		Queue:    q,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Handle registers h to run Jobs for step
+func (w *Worker) Handle(step string, h Handler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.handlers == nil {
+		w.handlers = make(map[string]Handler)
+	}
+
+	w.handlers[step] = h
+}
+
+func (w *Worker) handler(step string) (Handler, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	h, ok := w.handlers[step]
+	return h, ok
+}
+
+func (w *Worker) delay(attempt int) time.Duration {
+	if w.Delay == nil {
+		return trier.ExponentialBackoff(time.Minute, time.Hour)(attempt)
+	}
+	return w.Delay(attempt)
+}
+
+// Try runs step's Handler with payload through
+// tr right away and, if it fails, enqueues it to
+// be pulled again later unless it's dropped.
+// The error is recorded in tr either way, so
+// the caller knows the step hasn't succeeded
+func (w *Worker) Try(ctx context.Context, tr *trier.Trier, step string, payload []byte) *trier.Trier {
+	if tr.Err() != nil {
+		return tr
+	}
+
+	err := w.run(ctx, Job{Step: step, Payload: payload})
+
+	return tr.Record(err)
+}
+
+// Process pulls a single Job from the Queue and
+// runs it, reporting whether there was one.
+// The only errors returned are from the Queue
+func (w *Worker) Process(ctx context.Context) (bool, error) {
+	job, err := w.Queue.Dequeue(ctx)
+	if errors.Is(err, ErrEmpty) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	err = w.run(ctx, job)
+
+	var queueErr *queueError
+	if errors.As(err, &queueErr) {
+		return true, queueErr.err
+	}
+
+	return true, nil
+}
+
+// Run processes Jobs until ctx is done,
+// returning ctx's error, or until the
+// Queue fails, returning its error
+func (w *Worker) Run(ctx context.Context) error {
+	interval := w.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	// a single timer is reused
+	// for every wait
+	timer := time.NewTimer(interval)
+	timer.Stop()
+
+	defer timer.Stop()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ok, err := w.Process(ctx)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			continue
+		}
+
+		timer.Reset(interval)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// queueError wraps an error from
+// putting a Job back on the Queue
+type queueError struct {
+	err error
+}
+
+func (e *queueError) Error() string {
+	return e.err.Error()
+}
+
+func (e *queueError) Unwrap() error {
+	return e.err
+}
+
+// run tries job and, if it fails, either puts
+// it back on the Queue or drops it
+func (w *Worker) run(ctx context.Context, job Job) error {
+	h, ok := w.handler(job.Step)
+	if !ok {
+		err := fmt.Errorf("trierqueue: no handler for step %q", job.Step)
+		w.drop(job, err)
+		return err
+	}
+
+	err := trier.NewTrier(trier.WithName(job.Step)).TryWith(func(args ...any) error {
+		return h(ctx, job.Payload)
+	}, trier.WithPolicy(w.Policy), trier.WithContext(ctx)).Err()
+	if err == nil {
+		return nil
+	}
+
+	job.LastError = err.Error()
+
+	// a Job cut short by ctx being done (such
+	// as at shutdown) didn't really fail, so
+	// it's put back as it was, to be pulled
+	// again straight away
+	if ctx.Err() != nil {
+		job.NextAttempt = time.Now()
+	} else {
+		job.Attempts++
+
+		if (w.Policy.RetryIf != nil && !w.Policy.RetryIf(err)) ||
+			(w.MaxAttempts > 0 && job.Attempts >= w.MaxAttempts) {
+			w.drop(job, err)
+			return err
+		}
+
+		job.NextAttempt = time.Now().Add(w.delay(job.Attempts - 1))
+	}
+
+	// the Job has already been dequeued, so it
+	// must be put back even if ctx is done
+	if qErr := w.Queue.Enqueue(context.WithoutCancel(ctx), job); qErr != nil {
+		return errors.Join(err, &queueError{err: qErr})
+	}
+
+	return err
+}
+
+func (w *Worker) drop(job Job, err error) {
+	if w.OnDrop != nil {
+		w.OnDrop(job, err)
+	}
+}
+
+// MemoryQueue is a Queue kept in memory. It
+// doesn't survive restarts, so it's mainly
+// useful for tests and as a reference for
+// implementing durable Queues
+type MemoryQueue struct {
+	mu   sync.Mutex
+	jobs []Job
+}
+
+// Enqueue adds job to the queue
+func (q *MemoryQueue) Enqueue(ctx context.Context, job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.jobs = append(q.jobs, job)
+
+	sort.SliceStable(q.jobs, func(i, j int) bool {
+		return q.jobs[i].NextAttempt.Before(q.jobs[j].NextAttempt)
+	})
+
+	return nil
+}
+
+// Dequeue removes and returns the Job that
+// has been due the longest, or ErrEmpty
+func (q *MemoryQueue) Dequeue(ctx context.Context) (Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.jobs) == 0 || q.jobs[0].NextAttempt.After(time.Now()) {
+		return Job{}, ErrEmpty
+	}
+
+	job := q.jobs[0]
+	q.jobs = q.jobs[1:]
+
+	return job, nil
+}
+
+// Len returns how many Jobs are queued
+func (q *MemoryQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.jobs)
+}
@@ -0,0 +1,319 @@
+package trierqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+var errFlaky = errors.New("flaky")
+
+var errBroken = errors.New("broken")
+
+func noDelay(attempt int) time.Duration {
+	return 0
+}
+
+// failQueue fails to enqueue anything
+type failQueue struct {
+	MemoryQueue
+}
+
+func (q *failQueue) Enqueue(ctx context.Context, job Job) error {
+	return errBroken
+}
+
+// ctxQueue refuses Jobs once ctx is done,
+// like a durable Queue honouring ctx would
+type ctxQueue struct {
+	MemoryQueue
+}
+
+func (q *ctxQueue) Enqueue(ctx context.Context, job Job) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return q.MemoryQueue.Enqueue(ctx, job)
+}
+
+func TestMemoryQueue(t *testing.T) {
+	// Arrange
+	q := &MemoryQueue{}
+	ctx := context.Background()
+
+	now := time.Now()
+
+	_ = q.Enqueue(ctx, Job{Step: "later", NextAttempt: now.Add(time.Hour)})
+	_ = q.Enqueue(ctx, Job{Step: "second", NextAttempt: now.Add(-time.Second)})
+	_ = q.Enqueue(ctx, Job{Step: "first", NextAttempt: now.Add(-time.Minute)})
+
+	// Act
+	first, err1 := q.Dequeue(ctx)
+	second, err2 := q.Dequeue(ctx)
+	_, err3 := q.Dequeue(ctx)
+
+	// Assert
+	assert.Nil(t, err1)
+	assert.Equal(t, "first", first.Step)
+	assert.Nil(t, err2)
+	assert.Equal(t, "second", second.Step)
+	assert.ErrorIs(t, err3, ErrEmpty)
+	assert.Equal(t, 1, q.Len())
+}
+
+func TestWorkerTry(t *testing.T) {
+	// Arrange
+	q := &MemoryQueue{}
+
+	w := NewWorker(q)
+	w.Delay = noDelay
+	w.Handle("import", func(ctx context.Context, payload []byte) error {
+		return nil
+	})
+
+	tr := trier.NewTrier()
+
+	// Act
+	w.Try(context.Background(), tr, "import", []byte("batch-1"))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestWorkerTryEnqueuesFailure(t *testing.T) {
+	// Arrange
+	q := &MemoryQueue{}
+
+	w := NewWorker(q)
+	w.Delay = func(attempt int) time.Duration {
+		return time.Hour
+	}
+	w.Handle("import", func(ctx context.Context, payload []byte) error {
+		return errFlaky
+	})
+
+	tr := trier.NewTrier()
+
+	// Act
+	w.Try(context.Background(), tr, "import", []byte("batch-1"))
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), errFlaky)
+	assert.Equal(t, 1, q.Len())
+
+	_, err := q.Dequeue(context.Background())
+	assert.ErrorIs(t, err, ErrEmpty)
+}
+
+func TestWorkerProcess(t *testing.T) {
+	// Arrange
+	q := &MemoryQueue{}
+
+	var payloads []string
+
+	w := NewWorker(q)
+	w.Delay = noDelay
+	w.Handle("import", func(ctx context.Context, payload []byte) error {
+		payloads = append(payloads, string(payload))
+		if len(payloads) < 3 {
+			return errFlaky
+		}
+		return nil
+	})
+
+	ctx := context.Background()
+
+	_ = q.Enqueue(ctx, Job{Step: "import", Payload: []byte("batch-1")})
+
+	// Act
+	var processed int
+	for {
+		ok, err := w.Process(ctx)
+		assert.Nil(t, err)
+		if !ok {
+			break
+		}
+		processed++
+	}
+
+	// Assert
+	assert.Equal(t, 3, processed)
+	assert.Equal(t, []string{"batch-1", "batch-1", "batch-1"}, payloads)
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestWorkerProcessMaxAttempts(t *testing.T) {
+	// Arrange
+	q := &MemoryQueue{}
+
+	var dropped Job
+
+	w := NewWorker(q)
+	w.Delay = noDelay
+	w.MaxAttempts = 2
+	w.OnDrop = func(job Job, err error) {
+		dropped = job
+	}
+	w.Handle("import", func(ctx context.Context, payload []byte) error {
+		return errFlaky
+	})
+
+	ctx := context.Background()
+
+	_ = q.Enqueue(ctx, Job{Step: "import"})
+
+	// Act
+	first, _ := w.Process(ctx)
+	second, _ := w.Process(ctx)
+	third, _ := w.Process(ctx)
+
+	// Assert
+	assert.True(t, first)
+	assert.True(t, second)
+	assert.False(t, third)
+	assert.Equal(t, 2, dropped.Attempts)
+	assert.Equal(t, "import: flaky", dropped.LastError)
+}
+
+func TestWorkerProcessShutdown(t *testing.T) {
+	// Arrange
+	q := &ctxQueue{}
+
+	dropped := false
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := NewWorker(q)
+	w.Delay = noDelay
+	w.MaxAttempts = 1
+	w.OnDrop = func(job Job, err error) {
+		dropped = true
+	}
+	w.Handle("import", func(ctx context.Context, payload []byte) error {
+		cancel()
+		return ctx.Err()
+	})
+
+	_ = q.Enqueue(ctx, Job{Step: "import", Attempts: 0})
+
+	// Act
+	ok, err := w.Process(ctx)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.False(t, dropped)
+
+	job, _ := q.Dequeue(context.Background())
+	assert.Equal(t, "import", job.Step)
+	assert.Equal(t, 0, job.Attempts)
+}
+
+func TestWorkerProcessPermanent(t *testing.T) {
+	// Arrange
+	q := &MemoryQueue{}
+
+	var dropped error
+
+	w := NewWorker(q)
+	w.Delay = noDelay
+	w.Policy = trier.RetryPolicy{
+		RetryIf: func(err error) bool {
+			return errors.Is(err, errFlaky)
+		},
+	}
+	w.OnDrop = func(job Job, err error) {
+		dropped = err
+	}
+	w.Handle("import", func(ctx context.Context, payload []byte) error {
+		return errBroken
+	})
+
+	ctx := context.Background()
+
+	_ = q.Enqueue(ctx, Job{Step: "import"})
+
+	// Act
+	ok, err := w.Process(ctx)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.ErrorIs(t, dropped, errBroken)
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestWorkerProcessUnknownStep(t *testing.T) {
+	// Arrange
+	q := &MemoryQueue{}
+
+	var dropped error
+
+	w := NewWorker(q)
+	w.OnDrop = func(job Job, err error) {
+		dropped = err
+	}
+
+	ctx := context.Background()
+
+	_ = q.Enqueue(ctx, Job{Step: "missing"})
+
+	// Act
+	ok, err := w.Process(ctx)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.EqualError(t, dropped, `trierqueue: no handler for step "missing"`)
+}
+
+func TestWorkerProcessQueueFails(t *testing.T) {
+	// Arrange
+	q := &failQueue{}
+
+	w := NewWorker(q)
+	w.Handle("import", func(ctx context.Context, payload []byte) error {
+		return errFlaky
+	})
+
+	ctx := context.Background()
+
+	_ = q.MemoryQueue.Enqueue(ctx, Job{Step: "import"})
+
+	// Act
+	ok, err := w.Process(ctx)
+
+	// Assert
+	assert.True(t, ok)
+	assert.ErrorIs(t, err, errBroken)
+}
+
+func TestWorkerRun(t *testing.T) {
+	// Arrange
+	q := &MemoryQueue{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := NewWorker(q)
+	w.Delay = noDelay
+	w.PollInterval = time.Millisecond
+	w.Handle("import", func(ctx context.Context, payload []byte) error {
+		cancel()
+		return nil
+	})
+
+	_ = q.Enqueue(ctx, Job{Step: "import"})
+
+	// Act
+	err := w.Run(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, q.Len())
+}
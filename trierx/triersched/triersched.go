@@ -0,0 +1,279 @@
+// Package triersched re-runs failed chains and
+// steps after a delay, for failures that need
+// minutes or hours to clear up rather than the
+// milliseconds in-memory retries wait for
+package triersched
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/syke99/trier"
+)
+
+// Task is something the Scheduler re-runs
+type Task func(ctx context.Context) error
+
+// ChainTask returns a Task executing c with args
+func ChainTask(c *trier.Chain, args ...any) Task {
+	return func(ctx context.Context) error {
+		_, err := c.Execute(args...)
+		return err
+	}
+}
+
+// StepTask returns a Task trying step
+// through a new Trier
+func StepTask(step trier.Step) Task {
+	return func(ctx context.Context) error {
+		return trier.NewTrier().TryStep(step).Err()
+	}
+}
+
+// Every returns a delay func that always waits d
+func Every(d time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		return d
+	}
+}
+
+// Entry describes a scheduled Task
+type Entry struct {
+	// ID identifies the Entry
+	ID string
+	// Name is the name it was scheduled with
+	Name string
+	// Attempts is how many times the
+	// Task has been re-run so far
+	Attempts int
+	// Limit is how many times the Task is
+	// re-run before it's given up on.
+	// Zero means it never is
+	Limit int
+	// NextRun is when the Task is next re-run
+	NextRun time.Time
+	// LastError is the message of the error
+	// the Task's latest run failed with
+	LastError string
+}
+
+// Store persists Entries, so they can be
+// loaded and resumed after a restart
+type Store interface {
+	// Save is called whenever an Entry is
+	// scheduled or rescheduled
+	Save(ctx context.Context, e Entry) error
+	// Delete is called once an Entry's Task
+	// succeeds or is given up on
+	Delete(ctx context.Context, id string) error
+}
+
+type scheduled struct {
+	entry Entry
+	delay func(attempt int) time.Duration
+	task  Task
+}
+
+// Scheduler re-runs Tasks once they're due
+// while Run is running, one at a time
+type Scheduler struct {
+	// Store, if set, is kept up to date
+	// with every scheduled Entry
+	Store Store
+	// OnGiveUp, if set, is called with any
+	// Entry that reaches its Limit and the
+	// error its Task last failed with
+	OnGiveUp func(e Entry, err error)
+	// OnStoreErr, if set, is called with any
+	// error from Store. Otherwise they're
+	// ignored, so the Task still runs
+	OnStoreErr func(err error)
+
+	mu      sync.Mutex
+	entries map[string]*scheduled
+	wake    chan struct{}
+}
+
+// NewScheduler returns an empty Scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		entries: make(map[string]*scheduled),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Schedule re-runs task, which has already
+// failed, after delay(0), then again after
+// delay(1) if that fails too, and so on,
+// up to limit times
+func (s *Scheduler) Schedule(ctx context.Context, name string, limit int, delay func(attempt int) time.Duration, task Task) Entry {
+	e := Entry{
+		ID:      newID(),
+		Name:    name,
+		Limit:   limit,
+		NextRun: time.Now().Add(delay(0)),
+	}
+
+	s.Resume(ctx, e, delay, task)
+
+	return e
+}
+
+// Resume schedules task to be re-run as
+// described by e, such as one loaded
+// from a Store after a restart
+func (s *Scheduler) Resume(ctx context.Context, e Entry, delay func(attempt int) time.Duration, task Task) {
+	s.mu.Lock()
+	s.entries[e.ID] = &scheduled{entry: e, delay: delay, task: task}
+	s.mu.Unlock()
+
+	s.save(ctx, e)
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Entries returns every scheduled Entry
+func (s *Scheduler) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]Entry, 0, len(s.entries))
+	for _, sc := range s.entries {
+		entries = append(entries, sc.entry)
+	}
+
+	return entries
+}
+
+// Run re-runs Tasks as they become due
+// until ctx is done, returning its error
+func (s *Scheduler) Run(ctx context.Context) error {
+	// a single timer is reused
+	// for every wait
+	timer := time.NewTimer(0)
+	timer.Stop()
+
+	defer timer.Stop()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		next, wait := s.next()
+
+		if next != nil && wait <= 0 {
+			s.run(ctx, next)
+			continue
+		}
+
+		var due <-chan time.Time
+
+		if next != nil {
+			timer.Reset(wait)
+			due = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-s.wake:
+		case <-due:
+		}
+
+		timer.Stop()
+	}
+}
+
+// next returns the Entry due soonest,
+// and how long until it's due
+func (s *Scheduler) next() (*scheduled, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next *scheduled
+	for _, sc := range s.entries {
+		if next == nil || sc.entry.NextRun.Before(next.entry.NextRun) {
+			next = sc
+		}
+	}
+
+	if next == nil {
+		return nil, 0
+	}
+
+	return next, time.Until(next.entry.NextRun)
+}
+
+func (s *Scheduler) run(ctx context.Context, sc *scheduled) {
+	err := sc.task(ctx)
+
+	// a run cut short by ctx being done (such
+	// as at shutdown) didn't really fail, so
+	// the Entry is left as it was, still due
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	// the run has happened, so the Store
+	// must hear of it even if ctx is done
+	ctx = context.WithoutCancel(ctx)
+
+	s.mu.Lock()
+
+	e := &sc.entry
+	e.Attempts++
+
+	if err == nil || (e.Limit > 0 && e.Attempts >= e.Limit) {
+		delete(s.entries, e.ID)
+		s.mu.Unlock()
+
+		s.delete(ctx, e.ID)
+
+		if err != nil {
+			e.LastError = err.Error()
+			if s.OnGiveUp != nil {
+				s.OnGiveUp(*e, err)
+			}
+		}
+
+		return
+	}
+
+	e.LastError = err.Error()
+	e.NextRun = time.Now().Add(sc.delay(e.Attempts))
+	entry := *e
+
+	s.mu.Unlock()
+
+	s.save(ctx, entry)
+}
+
+func (s *Scheduler) save(ctx context.Context, e Entry) {
+	if s.Store != nil {
+		s.storeErr(s.Store.Save(ctx, e))
+	}
+}
+
+func (s *Scheduler) delete(ctx context.Context, id string) {
+	if s.Store != nil {
+		s.storeErr(s.Store.Delete(ctx, id))
+	}
+}
+
+func (s *Scheduler) storeErr(err error) {
+	if err != nil && s.OnStoreErr != nil {
+		s.OnStoreErr(err)
+	}
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,244 @@
+package triersched
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+var errFlaky = errors.New("flaky")
+
+// memStore records what the
+// Scheduler asks it to persist
+type memStore struct {
+	mu      sync.Mutex
+	saved   map[string]Entry
+	deleted []string
+}
+
+func (m *memStore) Save(ctx context.Context, e Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.saved == nil {
+		m.saved = make(map[string]Entry)
+	}
+	m.saved[e.ID] = e
+
+	return nil
+}
+
+func (m *memStore) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.saved, id)
+	m.deleted = append(m.deleted, id)
+
+	return nil
+}
+
+// failUntil returns a Task failing until
+// its nth run, cancelling ctx once it
+// has succeeded
+func failUntil(n int, runs *int, cancel context.CancelFunc) Task {
+	return func(ctx context.Context) error {
+		*runs++
+		if *runs < n {
+			return errFlaky
+		}
+		cancel()
+		return nil
+	}
+}
+
+func TestSchedulerRun(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	store := &memStore{}
+
+	s := NewScheduler()
+	s.Store = store
+
+	runs := 0
+
+	e := s.Schedule(ctx, "import", 5, Every(time.Millisecond), failUntil(3, &runs, cancel))
+
+	// Act
+	err := s.Run(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 3, runs)
+	assert.Empty(t, s.Entries())
+	assert.Empty(t, store.saved)
+	assert.Equal(t, []string{e.ID}, store.deleted)
+}
+
+func TestSchedulerRunGivesUp(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	s := NewScheduler()
+
+	var gaveUp Entry
+	s.OnGiveUp = func(e Entry, err error) {
+		gaveUp = e
+		cancel()
+	}
+
+	runs := 0
+
+	s.Schedule(ctx, "import", 2, Every(time.Millisecond), failUntil(5, &runs, cancel))
+
+	// Act
+	s.Run(ctx)
+
+	// Assert
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, "import", gaveUp.Name)
+	assert.Equal(t, 2, gaveUp.Attempts)
+	assert.Equal(t, "flaky", gaveUp.LastError)
+}
+
+func TestSchedulerRunShutdown(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	store := &memStore{}
+
+	s := NewScheduler()
+	s.Store = store
+
+	gaveUp := false
+	s.OnGiveUp = func(e Entry, err error) {
+		gaveUp = true
+	}
+
+	e := s.Schedule(ctx, "import", 1, Every(time.Millisecond), func(ctx context.Context) error {
+		cancel()
+		return ctx.Err()
+	})
+
+	// Act
+	err := s.Run(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, gaveUp)
+	assert.Empty(t, store.deleted)
+	assert.Equal(t, []Entry{e}, s.Entries())
+	assert.Equal(t, e, store.saved[e.ID])
+}
+
+func TestSchedulerDelay(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	s := NewScheduler()
+
+	runs := 0
+
+	e := s.Schedule(ctx, "import", 0, Every(time.Hour), failUntil(1, &runs, cancel))
+
+	// Act
+	err := s.Run(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, runs)
+	assert.Equal(t, []Entry{e}, s.Entries())
+}
+
+func TestSchedulerResume(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	store := &memStore{}
+
+	s := NewScheduler()
+	s.Store = store
+
+	runs := 0
+
+	e := Entry{ID: "persisted", Name: "import", Attempts: 3, Limit: 5, NextRun: time.Now()}
+
+	// Act
+	s.Resume(ctx, e, Every(time.Millisecond), failUntil(1, &runs, cancel))
+	s.Run(ctx)
+
+	// Assert
+	assert.Equal(t, 1, runs)
+	assert.Equal(t, []string{"persisted"}, store.deleted)
+}
+
+func TestSchedulerWakesForNewEntries(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	s := NewScheduler()
+
+	runs := 0
+
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	// Act
+	time.Sleep(10 * time.Millisecond)
+	s.Schedule(ctx, "import", 0, Every(0), failUntil(1, &runs, cancel))
+
+	// Assert
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, 1, runs)
+}
+
+func TestStepTask(t *testing.T) {
+	// Arrange
+	task := StepTask(trier.Step{
+		Name: "import",
+		Fn: func(args ...any) error {
+			return errFlaky
+		},
+	})
+
+	// Act
+	err := task(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, errFlaky)
+}
+
+func TestChainTask(t *testing.T) {
+	// Arrange
+	var got []any
+
+	c := trier.NewChain().Then(trier.Step{
+		Fn: func(args ...any) error {
+			got = args
+			return nil
+		},
+	})
+
+	task := ChainTask(c, "batch-1")
+
+	// Act
+	err := task(context.Background())
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []any{"batch-1"}, got)
+}
@@ -0,0 +1,269 @@
+// Package triersql provides database/sql helpers
+// that retry transient failures, such as
+// serialization failures, deadlocks and
+// connection resets, with trier's retry engine
+package triersql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/syke99/trier"
+)
+
+// DefaultPolicy is used by a Retrier whose
+// Policy doesn't set Attempts
+var DefaultPolicy = trier.RetryPolicy{
+	Attempts: 5,
+	Backoff:  trier.ExponentialBackoff(10*time.Millisecond, time.Second),
+	Jitter:   0.2,
+	RetryIf:  IsTransient,
+}
+
+// transientStates are the SQLSTATE codes (or
+// classes, for those ending in "*") that are
+// worth retrying
+var transientStates = []string{
+	"40001", // serialization_failure
+	"40P01", // deadlock_detected
+	"08*",   // connection exceptions
+	"57P01", // admin_shutdown
+	"HYT00", // timeout expired
+}
+
+// transientMessages are matched against error
+// messages for drivers that don't expose a
+// SQLSTATE code
+var transientMessages = []string{
+	"deadlock",
+	"could not serialize",
+	"serialization failure",
+	"lock wait timeout",
+	"connection reset",
+	"broken pipe",
+}
+
+// IsTransient reports whether err is a
+// failure that is likely to succeed if
+// retried. It is driver-agnostic, checking
+// for driver.ErrBadConn, connection level
+// syscall errors, the SQLSTATE of errors
+// implementing SQLState() string (as
+// Postgres drivers do) and, failing that,
+// well known messages (as MySQL uses)
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var stater interface{ SQLState() string }
+	if errors.As(err, &stater) {
+		state := stater.SQLState()
+
+		for _, s := range transientStates {
+			if class, ok := strings.CutSuffix(s, "*"); ok {
+				if strings.HasPrefix(state, class) {
+					return true
+				}
+			} else if state == s {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+
+	for _, m := range transientMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Execer is implemented by *sql.DB and *sql.Conn
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Querier is implemented by *sql.DB and *sql.Conn
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// TxBeginner is implemented by *sql.DB and *sql.Conn
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// Retrier runs database operations
+// through a Trier with its Policy
+type Retrier struct {
+	// Policy controls retries. If Attempts
+	// isn't set, DefaultPolicy is used, and
+	// if RetryIf isn't set, IsTransient is
+	Policy trier.RetryPolicy
+}
+
+func (r Retrier) policy() trier.RetryPolicy {
+	policy := r.Policy
+	if policy.Attempts == 0 {
+		policy = DefaultPolicy
+	}
+
+	if policy.RetryIf == nil {
+		policy.RetryIf = IsTransient
+	}
+
+	return policy
+}
+
+func (r Retrier) options(ctx context.Context) []trier.TryOption {
+	return []trier.TryOption{trier.WithPolicy(r.policy()), trier.WithContext(ctx)}
+}
+
+// TryExec executes query on db through tr,
+// retrying transient failures. Like any Try,
+// nothing is done if tr already holds an
+// error, in which case nil is returned.
+// Statements shouldn't be retried within a
+// transaction, so use TryTx to retry a whole
+// transaction instead
+func (r Retrier) TryExec(ctx context.Context, tr *trier.Trier, db Execer, query string, args ...any) sql.Result {
+	var res sql.Result
+
+	tr.TryWith(trier.Capture(&res, func() (sql.Result, error) {
+		return db.ExecContext(ctx, query, args...)
+	}), r.options(ctx)...)
+
+	return res
+}
+
+// TryQuery is like TryExec, but runs a query
+// returning rows. The caller must close the
+// returned *sql.Rows if it isn't nil
+func (r Retrier) TryQuery(ctx context.Context, tr *trier.Trier, db Querier, query string, args ...any) *sql.Rows {
+	var rows *sql.Rows
+
+	tr.TryWith(trier.Capture(&rows, func() (*sql.Rows, error) {
+		return db.QueryContext(ctx, query, args...)
+	}), r.options(ctx)...)
+
+	return rows
+}
+
+// TryTx runs fn inside a transaction begun on
+// db through tr, committing it if fn succeeds
+// and rolling it back otherwise. If beginning
+// or fn fails transiently, the whole
+// transaction is retried from the start. A
+// failed commit is only retried for a
+// serialization failure or deadlock (SQLSTATE
+// 40001 or 40P01), since after any other
+// failure, such as the connection dropping,
+// the commit may already have been applied
+func (r Retrier) TryTx(ctx context.Context, tr *trier.Trier, db TxBeginner, opts *sql.TxOptions, fn func(tx *sql.Tx) error) *trier.Trier {
+	policy := r.policy()
+
+	retryIf := policy.RetryIf
+	policy.RetryIf = func(err error) bool {
+		var ce *commitError
+		if errors.As(err, &ce) && !rolledBack(ce.err) {
+			return false
+		}
+		return retryIf(err)
+	}
+
+	return tr.TryWith(func(args ...any) error {
+		tx, err := db.BeginTx(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(tx); err != nil {
+			return errors.Join(err, ignoreDone(tx.Rollback()))
+		}
+
+		if err := tx.Commit(); err != nil {
+			return &commitError{err: err}
+		}
+
+		return nil
+	}, trier.WithPolicy(policy), trier.WithContext(ctx))
+}
+
+// commitError marks an error returned
+// by committing a transaction
+type commitError struct {
+	err error
+}
+
+func (e *commitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *commitError) Unwrap() error {
+	return e.err
+}
+
+// rolledBack reports whether err, returned
+// by a commit, has a SQLSTATE guaranteeing
+// the transaction was rolled back rather
+// than possibly applied
+func rolledBack(err error) bool {
+	var stater interface{ SQLState() string }
+	if !errors.As(err, &stater) {
+		return false
+	}
+
+	switch stater.SQLState() {
+	case "40001", "40P01":
+		return true
+	}
+	return false
+}
+
+// ignoreDone drops the error from rolling back
+// a transaction that is already finished
+func ignoreDone(err error) error {
+	if errors.Is(err, sql.ErrTxDone) {
+		return nil
+	}
+	return err
+}
+
+// TryExec is (Retrier{}).TryExec
+func TryExec(ctx context.Context, tr *trier.Trier, db Execer, query string, args ...any) sql.Result {
+	return Retrier{}.TryExec(ctx, tr, db, query, args...)
+}
+
+// TryQuery is (Retrier{}).TryQuery
+func TryQuery(ctx context.Context, tr *trier.Trier, db Querier, query string, args ...any) *sql.Rows {
+	return Retrier{}.TryQuery(ctx, tr, db, query, args...)
+}
+
+// TryTx is (Retrier{}).TryTx
+func TryTx(ctx context.Context, tr *trier.Trier, db TxBeginner, opts *sql.TxOptions, fn func(tx *sql.Tx) error) *trier.Trier {
+	return Retrier{}.TryTx(ctx, tr, db, opts, fn)
+}
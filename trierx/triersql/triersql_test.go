@@ -0,0 +1,305 @@
+package triersql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syke99/trier"
+)
+
+var fastPolicy = trier.RetryPolicy{
+	Attempts: 3,
+	Backoff: func(i int) time.Duration {
+		return time.Millisecond
+	},
+}
+
+type stateErr string
+
+func (e stateErr) Error() string {
+	return "sqlstate " + string(e)
+}
+
+func (e stateErr) SQLState() string {
+	return string(e)
+}
+
+// fakeState scripts how a fake
+// database behaves and counts
+// what was done to it
+type fakeState struct {
+	mu        sync.Mutex
+	failures  int
+	err       error
+	execs     int
+	commits   int
+	rollbacks int
+	// commitFailures and commitErr
+	// script how commits fail
+	commitFailures int
+	commitErr      error
+}
+
+func (s *fakeState) next() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.execs++
+
+	if s.failures > 0 {
+		s.failures--
+		return s.err
+	}
+	return nil
+}
+
+var fakes sync.Map
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	s, _ := fakes.Load(name)
+	return &fakeConn{s: s.(*fakeState)}, nil
+}
+
+type fakeConn struct {
+	s *fakeState
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return &fakeTx{s: c.s}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.s.next(); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.s.next(); err != nil {
+		return nil, err
+	}
+	return &fakeRows{}, nil
+}
+
+type fakeTx struct {
+	s *fakeState
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.s.mu.Lock()
+	defer tx.s.mu.Unlock()
+	tx.s.commits++
+
+	if tx.s.commitFailures > 0 {
+		tx.s.commitFailures--
+		return tx.s.commitErr
+	}
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.s.mu.Lock()
+	defer tx.s.mu.Unlock()
+	tx.s.rollbacks++
+	return nil
+}
+
+type fakeRows struct {
+	done bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"n"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+func init() {
+	sql.Register("triersql-fake", fakeDriver{})
+}
+
+func openFake(t *testing.T, failures int, err error) (*sql.DB, *fakeState) {
+	s := &fakeState{failures: failures, err: err}
+	fakes.Store(t.Name(), s)
+
+	db, _ := sql.Open("triersql-fake", t.Name())
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return db, s
+}
+
+func TestIsTransient(t *testing.T) {
+	// Arrange
+	cases := map[error]bool{
+		nil:                false,
+		stateErr("40001"):  true,
+		stateErr("40P01"):  true,
+		stateErr("08006"):  true,
+		stateErr("23505"):  false,
+		syscall.ECONNRESET: true,
+		driver.ErrBadConn:  true,
+		context.Canceled:   false,
+		errors.New("Error 1213 (40001): Deadlock found"): true,
+		errors.New("syntax error"):                       false,
+		fmt.Errorf("wrapped: %w", stateErr("40001")):     true,
+	}
+
+	for err, want := range cases {
+		// Act
+		got := IsTransient(err)
+
+		// Assert
+		assert.Equal(t, want, got, "%v", err)
+	}
+}
+
+func TestTryExec(t *testing.T) {
+	// Arrange
+	db, s := openFake(t, 2, stateErr("40001"))
+
+	tr := trier.NewTrier()
+
+	// Act
+	res := Retrier{Policy: fastPolicy}.TryExec(context.Background(), tr, db, "UPDATE t SET x = 1")
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	n, _ := res.RowsAffected()
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, 3, s.execs)
+}
+
+func TestTryExecPermanent(t *testing.T) {
+	// Arrange
+	db, s := openFake(t, 2, stateErr("23505"))
+
+	tr := trier.NewTrier()
+
+	// Act
+	res := Retrier{Policy: fastPolicy}.TryExec(context.Background(), tr, db, "INSERT INTO t VALUES (1)")
+
+	// Assert
+	assert.Equal(t, stateErr("23505"), tr.Err())
+	assert.Nil(t, res)
+	assert.Equal(t, 1, s.execs)
+}
+
+func TestTryQuery(t *testing.T) {
+	// Arrange
+	db, s := openFake(t, 1, stateErr("40P01"))
+
+	tr := trier.NewTrier()
+
+	// Act
+	rows := Retrier{Policy: fastPolicy}.TryQuery(context.Background(), tr, db, "SELECT n FROM t")
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	defer rows.Close()
+	assert.True(t, rows.Next())
+	assert.Equal(t, 2, s.execs)
+}
+
+func TestTryQueryPreviousError(t *testing.T) {
+	// Arrange
+	db, s := openFake(t, 0, nil)
+
+	tr := trier.NewTrier().TryFunc(func() error {
+		return errors.New("failed")
+	})
+
+	// Act
+	rows := TryQuery(context.Background(), tr, db, "SELECT n FROM t")
+
+	// Assert
+	assert.Nil(t, rows)
+	assert.Equal(t, 0, s.execs)
+}
+
+func TestTryTx(t *testing.T) {
+	// Arrange
+	db, s := openFake(t, 1, stateErr("40001"))
+
+	tr := trier.NewTrier()
+
+	// Act
+	Retrier{Policy: fastPolicy}.TryTx(context.Background(), tr, db, nil, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(context.Background(), "UPDATE t SET x = 1")
+		return err
+	})
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, 1, s.rollbacks)
+	assert.Equal(t, 1, s.commits)
+}
+
+func TestTryTxCommitSerializationFailure(t *testing.T) {
+	// Arrange
+	db, s := openFake(t, 0, nil)
+	s.commitFailures, s.commitErr = 1, stateErr("40001")
+
+	tr := trier.NewTrier()
+
+	// Act
+	Retrier{Policy: fastPolicy}.TryTx(context.Background(), tr, db, nil, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(context.Background(), "UPDATE t SET x = 1")
+		return err
+	})
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, 2, s.commits)
+}
+
+func TestTryTxCommitConnectionFailure(t *testing.T) {
+	// Arrange
+	db, s := openFake(t, 0, nil)
+	s.commitFailures, s.commitErr = 1, syscall.ECONNRESET
+
+	tr := trier.NewTrier()
+
+	// Act
+	Retrier{Policy: fastPolicy}.TryTx(context.Background(), tr, db, nil, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(context.Background(), "UPDATE t SET x = 1")
+		return err
+	})
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), syscall.ECONNRESET)
+	assert.Equal(t, 1, s.commits)
+	assert.Equal(t, 1, s.execs)
+}
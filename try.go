@@ -0,0 +1,655 @@
+package trier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ErrTimeout is returned for an attempt
+// that didn't finish within the duration
+// given to WithTimeout
+var ErrTimeout = errors.New("try timed out")
+
+// ErrChainTimeout is returned once the
+// duration given to WithChainTimeout has
+// passed. The recorded error is always a
+// *ChainTimeoutError wrapping it
+var ErrChainTimeout = errors.New("chain timed out")
+
+// ChainTimeoutError identifies the step
+// that was in flight when the duration
+// given to WithChainTimeout ran out
+type ChainTimeoutError struct {
+	// Step is the zero-based index of the step
+	Step int
+	// Name is the step's name, if it has one
+	Name    string
+	Timeout time.Duration
+}
+
+func (e *ChainTimeoutError) Error() string {
+	step := fmt.Sprintf("step %d", e.Step)
+	if e.Name != "" {
+		step = fmt.Sprintf("%s (%s)", step, e.Name)
+	}
+	return fmt.Sprintf("%s after %s during %s", ErrChainTimeout, e.Timeout, step)
+}
+
+func (e *ChainTimeoutError) Unwrap() error {
+	return ErrChainTimeout
+}
+
+// PanicError is returned for an attempt
+// that panicked while WithRecover was set
+type PanicError struct {
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("recovered from panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value
+// if it was an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// TryOption configures how a single call
+// to TryWith tries its function
+type TryOption func(c *tryConfig)
+
+type tryConfig struct {
+	args    []any
+	name    string
+	limit   int
+	backoff func(i int) time.Duration
+	jitter  float64
+	retryIf func(err error) bool
+	onRetry func(attempt int, err error)
+	ctx     context.Context
+	plain   func() error
+	sem     Semaphore
+	weight  int64
+	timeout time.Duration
+	recover bool
+	errFn   func(err error) error
+	join    bool
+
+	errFnMode ErrFnMode
+
+	// guards are the arguments that must
+	// be set for the method being called
+	guards guards
+}
+
+func newTryConfig(opts []TryOption) tryConfig {
+	c := tryConfig{limit: 1}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// WithArgs sets the args fn is called with
+func WithArgs(args ...any) TryOption {
+	return func(c *tryConfig) {
+		c.args = args
+	}
+}
+
+// WithStepName wraps any error recorded for
+// the call in a *StepError carrying name
+func WithStepName(name string) TryOption {
+	return func(c *tryConfig) {
+		c.name = name
+	}
+}
+
+// WithRetry tries fn up to limit times until
+// it doesn't error. If limit is less than or
+// equal to zero, fn is retried until it
+// doesn't error. If every attempt fails,
+// the errors from each attempt are joined
+// newest first, as they are for TryRetry
+func WithRetry(limit int) TryOption {
+	return func(c *tryConfig) {
+		c.limit = limit
+	}
+}
+
+// WithBackoff waits for the time.Duration
+// returned by backoff before each retry,
+// where i is the number of the attempt
+// that just failed, starting from zero
+func WithBackoff(backoff func(i int) time.Duration) TryOption {
+	return func(c *tryConfig) {
+		c.backoff = backoff
+	}
+}
+
+// WithJitter randomizes every backoff by up to
+// fraction of its length in either direction
+// (e.g. 0.2 turns a 1s backoff into anywhere
+// from 800ms to 1.2s), so many callers retrying
+// at once don't all retry in lockstep
+func WithJitter(fraction float64) TryOption {
+	return func(c *tryConfig) {
+		c.jitter = fraction
+	}
+}
+
+// WithRetryIf only retries errors for which
+// retryIf returns true. Any other error is
+// recorded straight away without retrying
+func WithRetryIf(retryIf func(err error) bool) TryOption {
+	return func(c *tryConfig) {
+		c.retryIf = retryIf
+	}
+}
+
+// WithOnRetry calls onRetry every time a failed
+// attempt is about to be retried, with the number
+// of attempts made so far and the error the
+// last one failed with, before any backoff
+func WithOnRetry(onRetry func(attempt int, err error)) TryOption {
+	return func(c *tryConfig) {
+		c.onRetry = onRetry
+	}
+}
+
+// WithContext stops retrying once ctx is
+// done, including part way through a backoff,
+// recording ctx.Err(). fn isn't passed ctx,
+// so if it should be canceled too it needs
+// to use ctx itself
+func WithContext(ctx context.Context) TryOption {
+	return func(c *tryConfig) {
+		c.ctx = ctx
+	}
+}
+
+// WithSemaphore acquires weight from sem
+// before fn is first tried, and releases it
+// once it has stopped being retried. If
+// weight can't be acquired (such as when
+// the context given to WithContext is done),
+// the error from acquiring it is recorded
+func WithSemaphore(sem Semaphore, weight int64) TryOption {
+	return func(c *tryConfig) {
+		c.sem = sem
+		c.weight = weight
+	}
+}
+
+// WithTimeout fails any attempt that takes
+// longer than d with ErrTimeout. fn can't be
+// interrupted, so it is left to finish in
+// the background
+func WithTimeout(d time.Duration) TryOption {
+	return func(c *tryConfig) {
+		c.timeout = d
+	}
+}
+
+// WithRecover recovers any panic raised by
+// fn and turns it into a *PanicError
+func WithRecover() TryOption {
+	return func(c *tryConfig) {
+		c.recover = true
+	}
+}
+
+// ErrFnMode controls what the errFn
+// given to WithOnErr is passed
+type ErrFnMode int
+
+const (
+	// EachAttempt passes errFn the error
+	// from each failed attempt as is
+	EachAttempt ErrFnMode = iota
+	// Aggregate passes errFn the errors from
+	// every failed attempt so far, joined
+	// newest first, with whatever errFn
+	// returned for the previous attempt
+	// standing in for the attempts before it
+	Aggregate
+)
+
+// WithErrFnMode sets what the errFn given
+// to WithOnErr is passed. The default
+// is EachAttempt
+func WithErrFnMode(mode ErrFnMode) TryOption {
+	return func(c *tryConfig) {
+		c.errFnMode = mode
+	}
+}
+
+// WithOnErr passes the error from every failed
+// attempt to errFn before it is recorded. If
+// errFn returns nil, the attempt is treated
+// as having succeeded
+func WithOnErr(errFn func(err error) error) TryOption {
+	return func(c *tryConfig) {
+		c.errFn = errFn
+	}
+}
+
+// WithJoin tries fn even if the Trier already
+// holds an error, joining any new error with
+// it, just like TryJoin
+func WithJoin() TryOption {
+	return func(c *tryConfig) {
+		c.join = true
+	}
+}
+
+// TryWith is the most general form of Try.
+// By default it behaves just like Try, and
+// opts compose any combination of args,
+// retries, backoff, timeouts, panic recovery
+// and error transformation for this call
+func (t *Trier) TryWith(fn func(args ...any) error, opts ...TryOption) *Trier {
+	if len(opts) == 0 {
+		return t.tryFast(fn, tryConfig{limit: 1})
+	}
+
+	c := newTryConfig(opts)
+
+	t.try(fn, &c)
+
+	return t
+}
+
+// try runs fn according to c, recording
+// any resulting error, and reports how many
+// attempts were made along with that error
+func (t *Trier) try(fn func(args ...any) error, c *tryConfig) (int, error) {
+	if t.timedOut || (t.failed() && !c.join) {
+		return 0, nil
+	}
+
+	// a shed step still counts as a step, so
+	// Chain's Resume knows it's the one to
+	// try again rather than the one before
+	step := t.steps
+	t.steps++
+
+	if t.budget != nil && t.budget.Exhausted() {
+		if !t.shed {
+			t.shed = true
+			t.record(ErrBudgetExhausted)
+		}
+		return 0, ErrBudgetExhausted
+	}
+
+	if err := c.validate(fn); err != nil {
+		return 0, t.result(step, c.name, 0, t.guard(step, c.name, err))
+	}
+
+	if c.sem != nil {
+		ctx := c.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		if err := c.sem.Acquire(ctx, c.weight); err != nil {
+			return 0, t.result(step, c.name, 0, t.record(err))
+		}
+
+		defer c.sem.Release(c.weight)
+	}
+
+	var errs []error
+
+	attempts := 0
+	for {
+		if c.ctx != nil && c.ctx.Err() != nil {
+			errs = append(errs, c.ctx.Err())
+			break
+		}
+
+		attempts++
+
+		err := t.attempt(fn, c, step)
+		if t.timedOut {
+			errs = append(errs, err)
+			break
+		}
+
+		raw := err
+		retry := err != nil && (c.retryIf == nil || c.retryIf(err))
+
+		aggregate := err != nil && c.errFn != nil && c.errFnMode == Aggregate
+
+		switch {
+		case aggregate:
+			err = c.errFn(join(collect(errs, err)))
+		case err != nil && c.errFn != nil:
+			err = c.errFn(err)
+		}
+
+		if err == nil {
+			errs = nil
+			break
+		}
+
+		// errors are only kept for a limited
+		// number of attempts, or when they
+		// can't be retried, and only the
+		// latest with WithFirstErrorOnly.
+		// With the Aggregate ErrFnMode, what
+		// errFn made of them replaces them
+		if t.firstOnly || aggregate {
+			errs = append(errs[:0], err)
+		} else if c.limit > 0 || !retry {
+			errs = collect(errs, err)
+		}
+
+		if !retry || (c.limit > 0 && attempts >= c.limit) {
+			break
+		}
+
+		if c.onRetry != nil {
+			c.onRetry(attempts, raw)
+		}
+
+		if delay := c.delay(attempts-1, raw, t.rnd); delay > 0 {
+			if err := t.sleep(delay, c, step); err != nil {
+				errs = append(errs, err)
+				break
+			}
+		}
+	}
+
+	err := join(errs)
+
+	if err != nil && attempts > 1 {
+		err = &RetryError{Err: err, Attempts: attempts, Limit: c.limit}
+	}
+
+	if err != nil && (c.name != "" || t.annotate) {
+		err = &StepError{Index: step, Name: c.name, Err: err}
+	}
+
+	if err != nil && t.budget != nil {
+		t.budget.Fail()
+	}
+
+	return attempts, t.result(step, c.name, attempts, t.record(err))
+}
+
+// result keeps a StepResult for the step at
+// index step if WithStepResults was given,
+// returning err as it was recorded
+func (t *Trier) result(step int, name string, attempts int, err error) error {
+	if t.keepSteps {
+		t.results = append(t.results, StepResult{
+			Index:    step,
+			Name:     name,
+			Attempts: attempts,
+			Err:      err,
+		})
+	}
+
+	return err
+}
+
+// join joins errs, held oldest first, into
+// one error newest first, just as a Trier
+// joins the errors of its steps, returning
+// a lone error as is
+func join(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	newest := slices.Clone(errs)
+	slices.Reverse(newest)
+
+	return errors.Join(newest...)
+}
+
+// RetryError wraps the error recorded for a
+// step that was retried, telling how many
+// attempts it made before giving up, so that
+// can be told apart from failing just once.
+// Its message is that of Err, so wrapping it
+// doesn't change how errors read
+type RetryError struct {
+	Err error
+	// Attempts is how many attempts were made
+	Attempts int
+	// Limit is how many were allowed, or
+	// zero or less if there was no limit
+	Limit int
+}
+
+func (e *RetryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Exhausted reports whether the step gave
+// up because it ran out of attempts, rather
+// than because its error couldn't be retried
+// or its context was done
+func (e *RetryError) Exhausted() bool {
+	return e.Limit > 0 && e.Attempts >= e.Limit
+}
+
+// RetryInfo returns the *RetryError for the
+// newest retried step in err, if there is one
+func RetryInfo(err error) (*RetryError, bool) {
+	var re *RetryError
+	ok := errors.As(err, &re)
+	return re, ok
+}
+
+// RepeatedError stands in for an error that
+// several attempts in a row failed with, so
+// a step retried thousands of times keeps
+// one copy of it rather than thousands
+type RepeatedError struct {
+	Err   error
+	Count int
+
+	msg string
+}
+
+func (e *RepeatedError) Error() string {
+	return fmt.Sprintf("%s (%d times)", e.msg, e.Count)
+}
+
+func (e *RepeatedError) Unwrap() error {
+	return e.Err
+}
+
+// collect appends err to the errors from
+// earlier attempts, folding it into the
+// latest of them if their messages match
+func collect(errs []error, err error) []error {
+	if len(errs) == 0 {
+		return append(errs, err)
+	}
+
+	msg := err.Error()
+
+	switch last := errs[len(errs)-1].(type) {
+	case *RepeatedError:
+		if last.msg == msg {
+			last.Count++
+			return errs
+		}
+	default:
+		if last.Error() == msg {
+			errs[len(errs)-1] = &RepeatedError{Err: last, Count: 2, msg: msg}
+			return errs
+		}
+	}
+
+	return append(errs, err)
+}
+
+// attempt makes a single attempt at fn,
+// bounded by both the per-call timeout
+// and any remaining chain timeout
+func (t *Trier) attempt(fn func(args ...any) error, c *tryConfig, step int) error {
+	if t.deadline.IsZero() {
+		return c.call(fn, c.timeout, nil)
+	}
+
+	remaining := time.Until(t.deadline)
+	if remaining <= 0 {
+		return t.expire(c, step)
+	}
+
+	if c.timeout > 0 && c.timeout < remaining {
+		return c.call(fn, c.timeout, nil)
+	}
+
+	err := c.call(fn, remaining, errChainExpired)
+	if err == errChainExpired {
+		err = t.expire(c, step)
+	}
+
+	return err
+}
+
+// errChainExpired signals from call that
+// the chain timeout ran out mid-attempt
+var errChainExpired = errors.New("chain expired")
+
+// delay returns how long to wait before
+// retrying after the i-th attempt failed
+// with err. An error implementing
+// RetryAfter() time.Duration (such as one
+// built from a Retry-After header) takes
+// precedence over any backoff
+func (c *tryConfig) delay(i int, err error, rnd *source) time.Duration {
+	var ra interface{ RetryAfter() time.Duration }
+	if errors.As(err, &ra) {
+		if d := ra.RetryAfter(); d > 0 {
+			return d
+		}
+	}
+
+	if c.backoff == nil {
+		return 0
+	}
+
+	d := c.backoff(i)
+
+	if c.jitter > 0 {
+		d += time.Duration((rnd.Float64()*2 - 1) * c.jitter * float64(d))
+	}
+
+	return d
+}
+
+// sleep waits for d, unless the chain
+// timeout runs out or the call's
+// context is done first
+func (t *Trier) sleep(d time.Duration, c *tryConfig, step int) error {
+	expired := false
+
+	if !t.deadline.IsZero() {
+		remaining := time.Until(t.deadline)
+		if remaining <= d {
+			d = max(remaining, 0)
+			expired = true
+		}
+	}
+
+	var done <-chan struct{}
+	if c.ctx != nil {
+		done = c.ctx.Done()
+	}
+
+	timer := getTimer(d)
+	defer putTimer(timer)
+
+	select {
+	case <-timer.C:
+	case <-done:
+		return c.ctx.Err()
+	}
+
+	if expired {
+		return t.expire(c, step)
+	}
+
+	return nil
+}
+
+// expire marks the chain as timed out and
+// returns the error identifying step
+func (t *Trier) expire(c *tryConfig, step int) error {
+	t.timedOut = true
+
+	return &ChainTimeoutError{Step: step, Name: c.name, Timeout: t.chainTimeout}
+}
+
+// call makes a single attempt at fn,
+// failing it with timeoutErr if it takes
+// longer than timeout
+func (c *tryConfig) call(fn func(args ...any) error, timeout time.Duration, timeoutErr error) error {
+	if timeout <= 0 {
+		return invoke(fn, c.plain, c.args, c.recover)
+	}
+
+	if timeoutErr == nil {
+		timeoutErr = fmt.Errorf("%w after %s", ErrTimeout, timeout)
+	}
+
+	done := make(chan error, 1)
+
+	// neither c nor its args are captured,
+	// so they can stay on the stack for
+	// calls without a timeout
+	plain, args, recovering := c.plain, append([]any(nil), c.args...), c.recover
+
+	go func() {
+		done <- invoke(fn, plain, args, recovering)
+	}()
+
+	timer := getTimer(timeout)
+	defer putTimer(timer)
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return timeoutErr
+	}
+}
+
+// invoke calls plain if it's set, or fn
+// with args otherwise, turning a panic
+// into a *PanicError if recovering is set
+func invoke(fn func(args ...any) error, plain func() error, args []any, recovering bool) (err error) {
+	if recovering {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{Value: r}
+			}
+		}()
+	}
+
+	if plain != nil {
+		return plain()
+	}
+
+	return fn(args...)
+}
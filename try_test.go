@@ -0,0 +1,464 @@
+package trier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrierTryWith(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryWith(passOrFail)
+
+	// Assert
+	assert.Nil(t, tr.Err())
+}
+
+func TestTrierTryWithArgs(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryWith(passOrFail, WithArgs(true))
+
+	// Assert
+	assert.Equal(t, "failed passOrFail", tr.Err().Error())
+}
+
+func TestTrierTryWithRetry(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		calls++
+		if calls < 3 {
+			return fail()
+		}
+		return nil
+	}, WithRetry(5))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, 3, calls)
+}
+
+func TestTrierTryWithRetryExhausted(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		calls++
+		return fail()
+	}, WithRetry(2))
+
+	// Assert
+	assert.Equal(t, "failed fail (2 times)", tr.Err().Error())
+	assert.Equal(t, 2, calls)
+}
+
+func TestTrierTryWithBackoff(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	var waited []int
+
+	// Act
+	tr.TryWith(passOrFail, WithArgs(true), WithRetry(3), WithBackoff(func(i int) time.Duration {
+		waited = append(waited, i)
+		return time.Millisecond
+	}))
+
+	// Assert
+	assert.NotNil(t, tr.Err())
+	assert.Equal(t, []int{0, 1}, waited)
+}
+
+func TestTrierTryWithTimeout(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}, WithTimeout(time.Millisecond))
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrTimeout)
+}
+
+func TestTrierTryWithRecover(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		panic("boom")
+	}, WithRecover())
+
+	// Assert
+	var panicErr *PanicError
+	assert.ErrorAs(t, tr.Err(), &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+}
+
+func TestTrierTryWithOnErr(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	errDomain := errors.New("domain error")
+
+	// Act
+	tr.TryWith(passOrFail, WithArgs(true), WithRetry(2), WithOnErr(func(err error) error {
+		return errDomain
+	}))
+
+	// Assert
+	assert.Equal(t, "domain error (2 times)", tr.Err().Error())
+}
+
+func TestTrierTryWithOnErrHandled(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryWith(passOrFail, WithArgs(true), WithOnErr(func(err error) error {
+		return nil
+	}))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+}
+
+func TestTrierTryRetryIfErrEveryAttempt(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	var seen []string
+
+	attempt := 0
+
+	// Act
+	tr.TryRetryIfErr(3, func(err error) error {
+		seen = append(seen, err.Error())
+		return fmt.Errorf("wrapped: %w", err)
+	}, func(args ...any) error {
+		attempt++
+		return fmt.Errorf("attempt %d", attempt)
+	})
+
+	// Assert
+	assert.Equal(t, []string{"attempt 1", "attempt 2", "attempt 3"}, seen)
+	assert.EqualError(t, tr.Err(), "wrapped: attempt 3\nwrapped: attempt 2\nwrapped: attempt 1")
+}
+
+func TestTrierTryWithErrFnModeAggregate(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	var seen []string
+
+	attempt := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		attempt++
+		return fmt.Errorf("attempt %d", attempt)
+	}, WithRetry(3), WithErrFnMode(Aggregate), WithOnErr(func(err error) error {
+		seen = append(seen, err.Error())
+		return fmt.Errorf("[%w]", err)
+	}))
+
+	// Assert
+	assert.Equal(t, []string{
+		"attempt 1",
+		"attempt 2\n[attempt 1]",
+		"attempt 3\n[attempt 2\n[attempt 1]]",
+	}, seen)
+	assert.EqualError(t, tr.Err(), "[attempt 3\n[attempt 2\n[attempt 1]]]")
+}
+
+func TestTrierTryWithErrFnModeAggregateHandled(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	attempt := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		attempt++
+		return fmt.Errorf("attempt %d", attempt)
+	}, WithRetry(5), WithErrFnMode(Aggregate), WithOnErr(func(err error) error {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok && len(joined.Unwrap()) >= 2 {
+			return nil
+		}
+		return err
+	}))
+
+	// Assert
+	assert.Nil(t, tr.Err())
+	assert.Equal(t, 2, attempt)
+}
+
+func TestTrierTryWithJoin(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.Try(passOrFail, true).
+		TryWith(failIfString, WithArgs("hi"), WithJoin())
+
+	// Assert
+	assert.Equal(t, "failedIfString\nfailed passOrFail", tr.Err().Error())
+}
+
+func TestTrierTryWithStepName(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryWith(passOrFail, WithArgs(true), WithStepName("step"))
+
+	// Assert
+	assert.Equal(t, "step: failed passOrFail", tr.Err().Error())
+}
+
+func TestTrierTryRetryBackoffInvalidLimit(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryRetryBackoff(0, func(i int) time.Duration {
+		return 0
+	}, passOrFail)
+
+	// Assert
+	assert.Equal(t, "step 0: retry backoff attempted with limit less than or equal to zero", tr.Err().Error())
+}
+
+type retryAfterErr time.Duration
+
+func (e retryAfterErr) Error() string {
+	return "retry after"
+}
+
+func (e retryAfterErr) RetryAfter() time.Duration {
+	return time.Duration(e)
+}
+
+func TestTrierTryWithRetryIf(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	errPermanent := errors.New("permanent")
+
+	calls := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		calls++
+		return errPermanent
+	}, WithRetry(0), WithRetryIf(func(err error) bool {
+		return !errors.Is(err, errPermanent)
+	}))
+
+	// Assert
+	assert.Equal(t, errPermanent, tr.Err())
+	assert.Equal(t, 1, calls)
+}
+
+func TestTrierTryWithContext(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return fail()
+	}, WithRetry(5), WithContext(ctx))
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), context.Canceled)
+	assert.Equal(t, 2, calls)
+}
+
+func TestTrierTryWithContextDuringBackoff(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	start := time.Now()
+	tr.TryWith(passOrFail, WithArgs(true), WithRetry(2), WithContext(ctx), WithBackoff(func(i int) time.Duration {
+		return time.Second
+	}))
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestTrierTryWithJitter(t *testing.T) {
+	// Arrange
+	c := newTryConfig([]TryOption{
+		WithBackoff(func(i int) time.Duration {
+			return time.Second
+		}),
+		WithJitter(0.5),
+	})
+
+	// Act
+	d := c.delay(0, fail(), nil)
+
+	// Assert
+	assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+	assert.LessOrEqual(t, d, 1500*time.Millisecond)
+}
+
+func TestTrierTryWithRetryAfter(t *testing.T) {
+	// Arrange
+	c := newTryConfig([]TryOption{
+		WithBackoff(func(i int) time.Duration {
+			return time.Second
+		}),
+	})
+
+	// Act
+	d := c.delay(0, fmt.Errorf("wrapped: %w", retryAfterErr(time.Minute)), nil)
+
+	// Assert
+	assert.Equal(t, time.Minute, d)
+}
+
+func TestTrierTryWithOnRetry(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	var retried []int
+
+	// Act
+	tr.TryWith(passOrFail, WithArgs(true), WithRetry(3), WithOnRetry(func(attempt int, err error) {
+		assert.Equal(t, "failed passOrFail", err.Error())
+		retried = append(retried, attempt)
+	}))
+
+	// Assert
+	assert.NotNil(t, tr.Err())
+	assert.Equal(t, []int{1, 2}, retried)
+}
+
+func TestTrierTryWithRetryRepeated(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	errFlaky := errors.New("flaky")
+
+	attempt := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		attempt++
+		if attempt == 3 {
+			return errors.New("different")
+		}
+		return errFlaky
+	}, WithRetry(1000))
+
+	// Assert
+	assert.EqualError(t, tr.Err(), "flaky (997 times)\ndifferent\nflaky (2 times)")
+	assert.ErrorIs(t, tr.Err(), errFlaky)
+
+	var repeated *RepeatedError
+	assert.True(t, errors.As(tr.Err(), &repeated))
+	assert.Equal(t, 997, repeated.Count)
+}
+
+func TestTrierTryRetryFirstFailure(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryRetry(2, passOrFail, true)
+
+	// Assert
+	assert.EqualError(t, tr.Err(), "failed passOrFail (2 times)")
+}
+
+func TestRetryInfoExhausted(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryRetry(3, passOrFail, true)
+
+	// Assert
+	info, ok := RetryInfo(tr.Err())
+	assert.True(t, ok)
+	assert.Equal(t, 3, info.Attempts)
+	assert.Equal(t, 3, info.Limit)
+	assert.True(t, info.Exhausted())
+}
+
+func TestRetryInfoNotRetryable(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	errPermanent := errors.New("permanent")
+
+	attempt := 0
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		attempt++
+		if attempt == 2 {
+			return errPermanent
+		}
+		return errors.New("flaky")
+	}, WithRetry(5), WithRetryIf(func(err error) bool {
+		return err != errPermanent
+	}))
+
+	// Assert
+	info, ok := RetryInfo(tr.Err())
+	assert.True(t, ok)
+	assert.Equal(t, 2, info.Attempts)
+	assert.False(t, info.Exhausted())
+}
+
+func TestRetryInfoSingleFailure(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryWith(func(args ...any) error {
+		return errors.New("nope")
+	}, WithRetry(3), WithRetryIf(func(err error) bool {
+		return false
+	}))
+
+	// Assert
+	_, ok := RetryInfo(tr.Err())
+	assert.False(t, ok)
+}
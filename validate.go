@@ -0,0 +1,67 @@
+package trier
+
+import "errors"
+
+// ErrInvalidLimit is recorded by the
+// TryRetryBackoff methods when limit is
+// less than or equal to zero, since they
+// refuse to retry forever
+var ErrInvalidLimit = errors.New("retry backoff attempted with limit less than or equal to zero")
+
+// ErrNilFunc is recorded in place of
+// calling a nil fn, rather than panicking.
+// Like every error found validating a step,
+// it's wrapped in a *StepError telling
+// which step it was
+var ErrNilFunc = errors.New("try attempted with nil func")
+
+// ErrNilBackoff is recorded by the
+// TryRetryBackoff methods when no
+// backoff func is given
+var ErrNilBackoff = errors.New("retry backoff attempted with nil backoff func")
+
+// ErrNilErrFn is recorded by the IfErr
+// methods when no errFn is given
+var ErrNilErrFn = errors.New("try attempted with nil errFn")
+
+// guards are the arguments, besides fn, that
+// a method requires to be set, such as the
+// errFn given to the IfErr methods
+type guards uint8
+
+const (
+	guardErrFn guards = 1 << iota
+	guardBackoff
+)
+
+// validate checks c, and the fn it's for,
+// against its guards, returning the error
+// to record in place of trying fn if any
+// of them are missing or invalid
+func (c *tryConfig) validate(fn func(args ...any) error) error {
+	switch {
+	case c.guards&guardBackoff != 0 && c.limit <= 0:
+		return ErrInvalidLimit
+	case c.guards&guardBackoff != 0 && c.backoff == nil:
+		return ErrNilBackoff
+	case c.guards&guardErrFn != 0 && c.errFn == nil:
+		return ErrNilErrFn
+	case fn == nil && c.plain == nil:
+		return ErrNilFunc
+	}
+	return nil
+}
+
+// guard records err, found while validating
+// the step at index step, as a *StepError
+// telling which step it was, or panics
+// with it if WithStrict was given
+func (t *Trier) guard(step int, name string, err error) error {
+	err = &StepError{Index: step, Name: name, Err: err}
+
+	if t.strict {
+		panic(err)
+	}
+
+	return t.record(err)
+}
@@ -0,0 +1,184 @@
+package trier
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrierTryRetryBackoffInvalidLimitIs(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryRetryBackoffFunc(-1, func(i int) time.Duration {
+		return 0
+	}, pass)
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrInvalidLimit)
+}
+
+func TestTrierTryRetryBackoffNilBackoff(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	// Act
+	tr.TryRetryBackoff(3, nil, func(args ...any) error {
+		calls++
+		return nil
+	})
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrNilBackoff)
+	assert.Equal(t, 0, calls)
+}
+
+func TestTrierTryNilFunc(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.Try(nil)
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrNilFunc)
+}
+
+func TestTrierTryWithNilFuncNamed(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryWith(nil, WithStepName("load"))
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrNilFunc)
+	assert.Equal(t, "load: try attempted with nil func", tr.Err().Error())
+}
+
+func TestTrierTryNilFuncNotRetried(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryRetry(0, nil)
+
+	// Assert
+	assert.Equal(t, &StepError{Index: 0, Err: ErrNilFunc}, tr.Err())
+}
+
+func TestTrierTryFuncNil(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryFunc(nil).
+		TryJoinFunc(nil)
+
+	// Assert
+	assert.Equal(t, "step 1: try attempted with nil func\nstep 0: try attempted with nil func", tr.Err().Error())
+}
+
+func TestTrierTryCtxNil(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryCtx(context.Background(), nil)
+
+	// Assert
+	assert.ErrorIs(t, tr.Err(), ErrNilFunc)
+}
+
+func TestTrySeqNil(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	TrySeq[int](tr, slices.Values([]int{1, 2}), nil)
+
+	// Assert
+	assert.Equal(t, &StepError{Index: 0, Err: ErrNilFunc}, tr.Err())
+}
+
+func TestTrierTryBatchNil(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryBatchParallel(2, []func() error{pass, nil, pass}, WithJoin())
+
+	// Assert
+	assert.Equal(t, &StepError{Index: 1, Err: ErrNilFunc}, tr.Err())
+}
+
+func TestTrierTryIfErrNilErrFn(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	calls := 0
+
+	// Act
+	tr.Try(passOrFail).
+		TryRetryIfErr(3, nil, func(args ...any) error {
+			calls++
+			return nil
+		})
+
+	// Assert
+	assert.Equal(t, &StepError{Index: 1, Err: ErrNilErrFn}, tr.Err())
+	assert.Equal(t, 0, calls)
+}
+
+func TestTrierTryRetryBackoffIfErrFuncGuards(t *testing.T) {
+	// Arrange
+	tr := NewTrier()
+
+	// Act
+	tr.TryJoinFunc(pass).
+		TryRetryBackoffIfErrFunc(2, nil, func(i int) time.Duration {
+			return 0
+		}, pass)
+
+	// Assert
+	assert.Equal(t, "step 1: try attempted with nil errFn", tr.Err().Error())
+}
+
+func TestWithStrict(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithStrict())
+
+	// Act
+	var recovered any
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+
+		tr.Try(passOrFail).
+			TryWith(nil, WithStepName("load"))
+	}()
+
+	// Assert
+	assert.Equal(t, &StepError{Index: 1, Name: "load", Err: ErrNilFunc}, recovered)
+	assert.Nil(t, tr.Err())
+}
+
+func TestWithStrictValid(t *testing.T) {
+	// Arrange
+	tr := NewTrier(WithStrict())
+
+	// Act
+	tr.TryRetryBackoff(2, func(i int) time.Duration {
+		return 0
+	}, passOrFail, true)
+
+	// Assert
+	assert.Equal(t, "failed passOrFail (2 times)", tr.Err().Error())
+}